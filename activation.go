@@ -0,0 +1,34 @@
+package cd_consts_go
+
+// заполняет Gate.Pers, Gate.Des и Gate.Defined по HdStructure.Hex планет личности и дизайна.
+// Активируют 13 тел карты (все кроме SSB), Хирон ворота не активирует.
+// HdStructure планет должны быть уже рассчитаны
+func (hd *HdInfo) ActivateGates() {
+
+	for i := 1; i < NUMBEROFGATES; i++ {
+		hd.Gates[i] = Gate{Number: i}
+	}
+
+	for body := 1; body < NUMBEROFPLANETS; body++ {
+
+		if hex := hd.Personality.Planet[body].Hex; hex > 0 && hex < NUMBEROFGATES {
+			hd.Gates[hex].Pers++
+			hd.Gates[hex].Defined = true
+		}
+
+		if hex := hd.Design.Planet[body].Hex; hex > 0 && hex < NUMBEROFGATES {
+			hd.Gates[hex].Des++
+			hd.Gates[hex].Defined = true
+		}
+	}
+}
+
+// пересчитывает HdStructure всех планет по их Longitude
+func (pl *Planets) SetHdStructures() {
+
+	for i := 1; i < NUMBEROFPLANETSWITHHIRON; i++ {
+		if p := pl.Body(i); p != nil {
+			p.HdStructure = NewHdStructure(p.Longitude)
+		}
+	}
+}
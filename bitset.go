@@ -0,0 +1,144 @@
+package cd_consts_go
+
+import "math/bits"
+
+// компактные множества для быстрого сравнения карт, годятся как ключи map
+
+// ворота 1 ... 64, бит gate-1
+type GateSet uint64
+
+// каналы 1 ... 36, бит ch-1
+type ChannelSet uint64
+
+// центры HEAD ... EMO, бит Center
+type CenterSet uint16
+
+func (gs GateSet) Has(gate int) bool {
+	return gate >= 1 && gate < NUMBEROFGATES && gs&(1<<(gate-1)) != 0
+}
+
+func (gs GateSet) With(gate int) GateSet {
+	if gate < 1 || gate >= NUMBEROFGATES {
+		return gs
+	}
+	return gs | 1<<(gate-1)
+}
+
+func (gs GateSet) Union(other GateSet) GateSet     { return gs | other }
+func (gs GateSet) Intersect(other GateSet) GateSet { return gs & other }
+func (gs GateSet) Count() int                      { return bits.OnesCount64(uint64(gs)) }
+
+// номера ворот по возрастанию
+func (gs GateSet) Gates() []int {
+
+	gates := make([]int, 0, gs.Count())
+	for v := uint64(gs); v != 0; v &= v - 1 {
+		gates = append(gates, bits.TrailingZeros64(v)+1)
+	}
+
+	return gates
+}
+
+// каналы, у которых активированы обе ворота
+func (gs GateSet) Channels() ChannelSet {
+
+	var cs ChannelSet
+	for ch := 1; ch < NUMBEROFCHANNELS; ch++ {
+		if gs.Has(ChannelTable[ch].FirstGate) && gs.Has(ChannelTable[ch].SecondGate) {
+			cs = cs.With(ch)
+		}
+	}
+
+	return cs
+}
+
+func (cs ChannelSet) Has(ch int) bool {
+	return ch >= 1 && ch < NUMBEROFCHANNELS && cs&(1<<(ch-1)) != 0
+}
+
+func (cs ChannelSet) With(ch int) ChannelSet {
+	if ch < 1 || ch >= NUMBEROFCHANNELS {
+		return cs
+	}
+	return cs | 1<<(ch-1)
+}
+
+func (cs ChannelSet) Union(other ChannelSet) ChannelSet     { return cs | other }
+func (cs ChannelSet) Intersect(other ChannelSet) ChannelSet { return cs & other }
+func (cs ChannelSet) Count() int                            { return bits.OnesCount64(uint64(cs)) }
+
+// номера каналов по возрастанию
+func (cs ChannelSet) Channels() []int {
+
+	channels := make([]int, 0, cs.Count())
+	for v := uint64(cs); v != 0; v &= v - 1 {
+		channels = append(channels, bits.TrailingZeros64(v)+1)
+	}
+
+	return channels
+}
+
+// центры, которые определяют каналы
+func (cs ChannelSet) Centers() CenterSet {
+
+	var centers CenterSet
+	for _, ch := range cs.Channels() {
+		centers = centers.With(ChannelTable[ch].FirstCenter).With(ChannelTable[ch].SecondCenter)
+	}
+
+	return centers
+}
+
+func (cs CenterSet) Has(c Center) bool {
+	return c >= 0 && c < NUMBEROFCENTERS && cs&(1<<c) != 0
+}
+
+func (cs CenterSet) With(c Center) CenterSet {
+	if c < 0 || c >= NUMBEROFCENTERS {
+		return cs
+	}
+	return cs | 1<<c
+}
+
+func (cs CenterSet) Union(other CenterSet) CenterSet     { return cs | other }
+func (cs CenterSet) Intersect(other CenterSet) CenterSet { return cs & other }
+func (cs CenterSet) Count() int                          { return bits.OnesCount16(uint16(cs)) }
+
+// активированные ворота карты
+func (hd *HdInfo) GateSet() GateSet {
+
+	var gs GateSet
+	for i := 1; i < NUMBEROFGATES; i++ {
+		if hd.Gates[i].Defined {
+			gs = gs.With(i)
+		}
+	}
+
+	return gs
+}
+
+// определенные каналы карты
+func (hd *HdInfo) ChannelSet() ChannelSet {
+
+	var cs ChannelSet
+	for i := 1; i < NUMBEROFCHANNELS; i++ {
+		if hd.Channels[i].Defined {
+			cs = cs.With(i)
+		}
+	}
+
+	return cs
+}
+
+// определенные центры карты
+func (hd *HdInfo) CenterSet() CenterSet {
+
+	var cs CenterSet
+	for c := Center(HEAD); c <= EMO; c++ {
+		if hd.Centers.Center[c] {
+			cs = cs.With(c)
+		}
+	}
+
+	return cs
+}
@@ -0,0 +1,173 @@
+package cd_consts_go
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGateSetBounds(t *testing.T) {
+
+	gs := GateSet(0).With(0).With(1).With(64).With(65)
+
+	tests := []struct {
+		gate int
+		want bool
+	}{
+		{0, false},
+		{1, true},
+		{64, true},
+		{65, false},
+	}
+
+	for _, tt := range tests {
+		if got := gs.Has(tt.gate); got != tt.want {
+			t.Errorf("Has(%d) = %v, want %v", tt.gate, got, tt.want)
+		}
+	}
+
+	if gs.Count() != 2 {
+		t.Errorf("Count() = %d, want 2", gs.Count())
+	}
+}
+
+func TestChannelSetBounds(t *testing.T) {
+
+	cs := ChannelSet(0).With(0).With(1).With(36).With(37)
+
+	tests := []struct {
+		ch   int
+		want bool
+	}{
+		{0, false},
+		{1, true},
+		{36, true},
+		{37, false},
+	}
+
+	for _, tt := range tests {
+		if got := cs.Has(tt.ch); got != tt.want {
+			t.Errorf("Has(%d) = %v, want %v", tt.ch, got, tt.want)
+		}
+	}
+
+	if cs.Count() != 2 {
+		t.Errorf("Count() = %d, want 2", cs.Count())
+	}
+}
+
+func TestSetOrdering(t *testing.T) {
+
+	gs := GateSet(0).With(64).With(3).With(33).With(1)
+	if got, want := gs.Gates(), []int{1, 3, 33, 64}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Gates() = %v, want %v", got, want)
+	}
+
+	cs := ChannelSet(0).With(36).With(2).With(20).With(1)
+	if got, want := cs.Channels(), []int{1, 2, 20, 36}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Channels() = %v, want %v", got, want)
+	}
+}
+
+func TestGateSetChannels(t *testing.T) {
+
+	info := ChannelTable[1]
+	gs := GateSet(0).With(info.FirstGate).With(info.SecondGate)
+
+	if got := gs.Channels(); got != ChannelSet(0).With(1) {
+		t.Errorf("Channels() = %v, want channel 1", got.Channels())
+	}
+
+	want := CenterSet(0).With(info.FirstCenter).With(info.SecondCenter)
+	if got := gs.Channels().Centers(); got != want {
+		t.Errorf("Centers() = %b, want %b", got, want)
+	}
+}
+
+// ворота двух карт в обоих представлениях: каждые 2-е и каждые 3-и
+func benchGates() (GateSet, GateSet, [NUMBEROFGATES]Gate, [NUMBEROFGATES]Gate) {
+
+	var a, b GateSet
+	var ga, gb [NUMBEROFGATES]Gate
+
+	for i := 1; i < NUMBEROFGATES; i++ {
+		ga[i] = Gate{Number: i, Defined: i%2 == 0}
+		gb[i] = Gate{Number: i, Defined: i%3 == 0}
+		if ga[i].Defined {
+			a = a.With(i)
+		}
+		if gb[i].Defined {
+			b = b.With(i)
+		}
+	}
+
+	return a, b, ga, gb
+}
+
+var benchCount int
+
+func BenchmarkGateSetIntersect(b *testing.B) {
+
+	x, y, _, _ := benchGates()
+
+	for i := 0; i < b.N; i++ {
+		benchCount = x.Intersect(y).Count()
+	}
+}
+
+func BenchmarkGateArrayIntersect(b *testing.B) {
+
+	_, _, x, y := benchGates()
+
+	for i := 0; i < b.N; i++ {
+		count := 0
+		for g := 1; g < NUMBEROFGATES; g++ {
+			if x[g].Defined && y[g].Defined {
+				count++
+			}
+		}
+		benchCount = count
+	}
+}
+
+func benchChannels() (ChannelSet, ChannelSet, [NUMBEROFCHANNELS]Channel, [NUMBEROFCHANNELS]Channel) {
+
+	var a, b ChannelSet
+	var ca, cb [NUMBEROFCHANNELS]Channel
+
+	for i := 1; i < NUMBEROFCHANNELS; i++ {
+		ca[i] = Channel{Number: i, Defined: i%2 == 0}
+		cb[i] = Channel{Number: i, Defined: i%3 == 0}
+		if ca[i].Defined {
+			a = a.With(i)
+		}
+		if cb[i].Defined {
+			b = b.With(i)
+		}
+	}
+
+	return a, b, ca, cb
+}
+
+func BenchmarkChannelSetIntersect(b *testing.B) {
+
+	x, y, _, _ := benchChannels()
+
+	for i := 0; i < b.N; i++ {
+		benchCount = x.Intersect(y).Count()
+	}
+}
+
+func BenchmarkChannelArrayIntersect(b *testing.B) {
+
+	_, _, x, y := benchChannels()
+
+	for i := 0; i < b.N; i++ {
+		count := 0
+		for ch := 1; ch < NUMBEROFCHANNELS; ch++ {
+			if x[ch].Defined && y[ch].Defined {
+				count++
+			}
+		}
+		benchCount = count
+	}
+}
@@ -0,0 +1,224 @@
+package cd_consts_go
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// язык каталога описаний
+type Locale string
+
+const (
+	English Locale = "en"
+	Russian Locale = "ru"
+)
+
+// версия формата каталога, поле version в файле
+const CatalogVersion = "1.0.0"
+
+//go:embed catalog/*.json
+var catalogFiles embed.FS
+
+// описание ворот: название, гексаграмма И-Цзин, ключевая тема ворот
+// и темы шести линий этих ворот (Lines)
+type GateEntry struct {
+	Name    string         `json:"name,omitempty"`
+	IChing  string         `json:"iching,omitempty"`
+	Keynote string         `json:"keynote,omitempty"`
+	Lines   map[int]string `json:"lines,omitempty"`
+}
+
+// каталог описаний ворот, линий и центров на одном языке
+type Catalog struct {
+	Version string            `json:"version"`
+	Locale  Locale            `json:"locale"`
+	Centers map[Center]string `json:"centers,omitempty"`
+	Lines   map[int]string    `json:"lines,omitempty"` // общие темы линий 1 ... 6, если у ворот нет своей
+	Gates   map[int]GateEntry `json:"gates,omitempty"` // ворота 1 ... 64
+}
+
+// встроенный каталог. Русский дополняется английским там, где нет перевода
+func LoadCatalog(locale Locale) (*Catalog, error) {
+
+	c, err := embeddedCatalog(English)
+	if err != nil || locale == English {
+		return c, err
+	}
+
+	loc, err := embeddedCatalog(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Merge(loc)
+
+	return c, nil
+}
+
+// встроенный каталог, поверх которого накладываются свои формулировки из r
+func LoadCatalogOverride(locale Locale, r io.Reader) (*Catalog, error) {
+
+	c, err := LoadCatalog(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	override, err := ParseCatalog(r)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Merge(override)
+
+	return c, nil
+}
+
+func embeddedCatalog(locale Locale) (*Catalog, error) {
+
+	f, err := catalogFiles.Open("catalog/" + string(locale) + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("LoadCatalog: unknown locale %q", locale)
+	}
+	defer f.Close()
+
+	return ParseCatalog(f)
+}
+
+// каталог в формате встроенных файлов; version можно не указывать,
+// другая версия формата - ошибка, ворота и линии вне диапазона - ошибка
+func ParseCatalog(r io.Reader) (*Catalog, error) {
+
+	var c Catalog
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return nil, fmt.Errorf("ParseCatalog: %w", err)
+	}
+
+	if c.Version == "" {
+		c.Version = CatalogVersion
+	}
+	if c.Version != CatalogVersion {
+		return nil, fmt.Errorf("ParseCatalog: unsupported catalog version %q", c.Version)
+	}
+
+	for line := range c.Lines {
+		if line < 1 || line > 6 {
+			return nil, fmt.Errorf("ParseCatalog: wrong line %d", line)
+		}
+	}
+
+	for gate, entry := range c.Gates {
+		if gate < 1 || gate >= NUMBEROFGATES {
+			return nil, fmt.Errorf("ParseCatalog: wrong gate %d", gate)
+		}
+		for line := range entry.Lines {
+			if line < 1 || line > 6 {
+				return nil, fmt.Errorf("ParseCatalog: wrong line %d of gate %d", line, gate)
+			}
+		}
+	}
+
+	return &c, nil
+}
+
+// накладывает непустые значения other поверх c, Locale берется из other, если он задан
+func (c *Catalog) Merge(other *Catalog) {
+
+	if other.Locale != "" {
+		c.Locale = other.Locale
+	}
+
+	if c.Centers == nil {
+		c.Centers = make(map[Center]string)
+	}
+	for center, name := range other.Centers {
+		if name != "" {
+			c.Centers[center] = name
+		}
+	}
+
+	if c.Lines == nil {
+		c.Lines = make(map[int]string)
+	}
+	for line, theme := range other.Lines {
+		if theme != "" {
+			c.Lines[line] = theme
+		}
+	}
+
+	if c.Gates == nil {
+		c.Gates = make(map[int]GateEntry)
+	}
+	for gate, o := range other.Gates {
+
+		entry := c.Gates[gate]
+
+		if o.Name != "" {
+			entry.Name = o.Name
+		}
+		if o.IChing != "" {
+			entry.IChing = o.IChing
+		}
+		if o.Keynote != "" {
+			entry.Keynote = o.Keynote
+		}
+
+		if len(o.Lines) > 0 {
+			lines := make(map[int]string, len(entry.Lines)+len(o.Lines))
+			for line, theme := range entry.Lines {
+				lines[line] = theme
+			}
+			for line, theme := range o.Lines {
+				if theme != "" {
+					lines[line] = theme
+				}
+			}
+			entry.Lines = lines
+		}
+
+		c.Gates[gate] = entry
+	}
+}
+
+func (c *Catalog) Gate(gate int) GateEntry {
+	return c.Gates[gate]
+}
+
+func (c *Catalog) GateName(gate int) string {
+	return c.Gates[gate].Name
+}
+
+func (c *Catalog) IChingName(gate int) string {
+	return c.Gates[gate].IChing
+}
+
+func (c *Catalog) Keynote(gate int) string {
+	return c.Gates[gate].Keynote
+}
+
+// тема линии ворот, если для ворот своей нет - общая тема линии
+func (c *Catalog) LineTheme(gate, line int) string {
+
+	if theme, ok := c.Gates[gate].Lines[line]; ok {
+		return theme
+	}
+
+	return c.Lines[line]
+}
+
+// название центра, если в каталоге нет - Center.String()
+func (c *Catalog) CenterName(center Center) string {
+
+	if name, ok := c.Centers[center]; ok {
+		return name
+	}
+
+	return center.String()
+}
+
+// описание ворот и линии планеты по ее HdStructure, номер линии округляется вверх
+func (c *Catalog) Describe(hs HdStructure) (GateEntry, string) {
+	return c.Gate(hs.Hex), c.LineTheme(hs.Hex, int(math.Ceil(hs.Line)))
+}
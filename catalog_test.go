@@ -0,0 +1,90 @@
+package cd_consts_go
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedCatalogs(t *testing.T) {
+
+	for _, locale := range []Locale{English, Russian} {
+
+		c, err := LoadCatalog(locale)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Version != CatalogVersion || c.Locale != locale {
+			t.Errorf("%s: version %q locale %q", locale, c.Version, c.Locale)
+		}
+
+		// свой вариант для каждых ворот и линии, ключевая тема не повторяет название креста
+		for gate := 1; gate < NUMBEROFGATES; gate++ {
+
+			e := c.Gate(gate)
+			if e.Name == "" || e.IChing == "" || e.Keynote == "" {
+				t.Errorf("%s gate %d: empty field in %+v", locale, gate, e)
+			}
+			if strings.EqualFold(strings.TrimPrefix(e.Keynote, "The "), strings.TrimPrefix(juxtapositionCrosses[gate], "the ")) {
+				t.Errorf("%s gate %d: keynote %q copies the juxtaposition cross name", locale, gate, e.Keynote)
+			}
+
+			for line := 1; line <= 6; line++ {
+				if e.Lines[line] == "" {
+					t.Errorf("%s gate %d line %d: no theme", locale, gate, line)
+				}
+			}
+		}
+
+		for center := Center(HEAD); center <= EMO; center++ {
+			if c.Centers[center] == "" {
+				t.Errorf("%s: no name for %s", locale, center)
+			}
+		}
+	}
+}
+
+func TestCatalogDescribeRoundsLineUp(t *testing.T) {
+
+	c, err := LoadCatalog(English)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, theme := c.Describe(HdStructure{Hex: 1, Line: 2.3})
+
+	if entry.Name != c.GateName(1) || theme != c.LineTheme(1, 3) {
+		t.Errorf("Describe(1, line 2.3) = %q, want line 3 %q", theme, c.LineTheme(1, 3))
+	}
+}
+
+func TestCatalogOverride(t *testing.T) {
+
+	override := `{"centers": {"G": "Identity"}, "lines": {"4": "Networker"},
+		"gates": {"1": {"keynote": "Mine", "lines": {"3": "Own wording"}}, "2": {"lines": {}}}}`
+
+	c, err := LoadCatalogOverride(Russian, strings.NewReader(override))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.CenterName(G) != "Identity" || c.Keynote(1) != "Mine" || c.LineTheme(1, 3) != "Own wording" {
+		t.Errorf("override not applied: %q %q %q", c.CenterName(G), c.Keynote(1), c.LineTheme(1, 3))
+	}
+	if c.GateName(1) != "Самовыражение" || c.LineTheme(1, 4) != "Прыжок над бездной" {
+		t.Errorf("override removed embedded wording: %q %q", c.GateName(1), c.LineTheme(1, 4))
+	}
+	if c.Lines[4] != "Networker" {
+		t.Errorf("generic line 4 = %q", c.Lines[4])
+	}
+
+	for _, bad := range []string{`{"version": "2.0.0"}`, `{"gates": {"65": {}}}`, `{"gates": {"1": {"lines": {"7": "x"}}}}`} {
+		if _, err := ParseCatalog(strings.NewReader(bad)); err == nil {
+			t.Errorf("ParseCatalog(%s): want an error", bad)
+		}
+	}
+
+	if _, err := LoadCatalog("de"); err == nil {
+		t.Error("want an error for an unknown locale")
+	}
+}
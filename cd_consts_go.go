@@ -38,17 +38,10 @@ const (
 	MOON      = 11 // 5,14° (относительно эклиптики)
 	NORTHNODE = 12
 	SOUTHNODE = 13
-	HIRON     = 14
-
-	HEAD   = 0
-	AJNA   = 1
-	THROAT = 2
-	G      = 3
-	SACRAL = 4
-	ROOT   = 5
-	EGO    = 6
-	SPLEEN = 7
-	EMO    = 8
+	HIRON     = 14 // только при подключенном SPK с малыми телами, в de440s его нет
+
+	// NAIF код Хирона (2060 Chiron)
+	HIRON_NAIF_CODE = 2002060
 
 	NUMBEROFGATES    = 65 //from 1 to 64
 	NUMBEROFCHANNELS = 37 //from 1 to 36
@@ -56,6 +49,9 @@ const (
 	// from 0 to 13, don't count Hiron yet
 	NUMBEROFPLANETS = 14
 
+	// from 0 to 14, Hiron is calculated only if small-body SPK is attached
+	NUMBEROFPLANETSWITHHIRON = 15
+
 	// from 0 to 8
 	NUMBEROFCENTERS = 9
 
@@ -70,6 +66,12 @@ const (
 	// 	JD2000       float64 = 2451545.0 //12:00 UT on January 1, 2000
 	JD1950 = 2433282.5
 
+	// размер одной гексаграммы в десятичных градусах
+	OneHexInDec float64 = 5.625
+
+	// начало колеса - 25 ворота
+	WheelStartInDec float64 = 358.25
+
 	// размер одной линии в десятичных градусах
 	OneLineInDec float64 = 0.9375
 
@@ -109,13 +111,13 @@ type HdInfo struct {
 	Channels    [NUMBEROFCHANNELS]Channel
 	Centers     Centers
 	Phs
-	Variable string
+	Variable Variable
 	Psychology
 	Cross      Cross
 	Profile    string
-	Authority  string
-	Definition string
-	Type       string
+	Authority  Authority
+	Definition Definition
+	Type       EnergyType
 }
 
 func (hd *HdInfo) Init() {
@@ -125,6 +127,8 @@ func (hd *HdInfo) Init() {
 
 		if i < NUMBEROFCHANNELS {
 			hd.Channels[i].Number = i
+			hd.Channels[i].FirstGate = Gate{Number: ChannelTable[i].FirstGate}
+			hd.Channels[i].SecondGate = Gate{Number: ChannelTable[i].SecondGate}
 		}
 
 	}
@@ -143,7 +147,7 @@ type Gate struct {
 	Defined bool
 }
 
-// 36 каналов ДЧ
+// 36 каналов ДЧ, полное описание в ChannelTable
 /*
 
   1 - 64-47
@@ -204,7 +208,8 @@ type HdObjects struct {
 	Planets
 	Centers Centers
 	TimeData
-	Authority string
+	Authority Authority
+	Mode      ChartMode // геоцентрический по умолчанию
 }
 
 type Planet struct {
@@ -218,6 +223,29 @@ type Planet struct {
 
 type Planets struct {
 	Planet [NUMBEROFPLANETS]Planet
+
+	// Хирон, только если подключен SPK с малыми телами, иначе nil
+	Hiron *Planet `json:",omitempty"`
+}
+
+// тело по номеру из Planets, для HIRON - Hiron (nil, если Хирон не рассчитывается)
+func (pl *Planets) Body(i int) *Planet {
+
+	switch {
+	case i >= 0 && i < NUMBEROFPLANETS:
+		return &pl.Planet[i]
+	case i == HIRON:
+		return pl.Hiron
+	}
+
+	return nil
+}
+
+// включает расчет Хирона
+func (pl *Planets) EnableHiron() {
+	if pl.Hiron == nil {
+		pl.Hiron = &Planet{Name: "Hiron", Number: HIRON}
+	}
 }
 
 func (pl *Planets) Init() {
@@ -235,7 +263,7 @@ func (pl *Planets) Init() {
 	pl.Planet[11] = Planet{Name: "Moon", Number: 11}
 	pl.Planet[12] = Planet{Name: "NorthNode", Number: 12}
 	pl.Planet[13] = Planet{Name: "SouthNde", Number: 13}
-	//{Name: "Hiron", Number: 14},
+	pl.Hiron = nil
 
 }
 
@@ -407,7 +435,7 @@ var HexSortByDeg = map[int][2]float64{
 
 	45: {77.0, 82.625},
 
-	12: {82.625, 88.255},
+	12: {82.625, 88.25},
 
 	15: {88.25, 93.875},
 
@@ -508,55 +536,39 @@ var HexSortByDeg = map[int][2]float64{
 	25: {358.25, 3.875},
 }
 
+// Theme - среда, NutrType - тип питания (determination), Cognition - когнитивность
 type Phs struct {
-	Theme     string
-	NutrType  string
-	Cognition string
+	Theme     Environment
+	NutrType  Determination
+	Cognition Cognition
 }
 
+// Mind - перспектива (взгляд)
 type Psychology struct {
-	Motivation string
-	Mind       string
+	Motivation Motivation
+	Mind       Perspective
 }
 
+// First, Second - Солнце и Земля личности, Third, Forth - Солнце и Земля дизайна
 type Cross struct {
 	First  int
 	Second int
 	Third  int
 	Forth  int
+	Angle  CrossAngle
+	Name   string
 }
 
 type NumerologyInfo struct {
 }
 
+// определенность центров, индекс - Center (HEAD ... EMO)
 type Centers struct {
-	Center map[string]bool
+	Center [NUMBEROFCENTERS]bool
 }
 
 func (cent *Centers) Init() {
-	/*
-		cent.Centers[0] = Center{Name: "Head"}
-		cent.Centers[1] = Center{Name: "Ajna"}
-		cent.Centers[2] = Center{Name: "Throat"}
-		cent.Centers[3] = Center{Name: "G"}
-		cent.Centers[4] = Center{Name: "Sacral"}
-		cent.Centers[5] = Center{Name: "Root"}
-		cent.Centers[6] = Center{Name: "Ego"}
-		cent.Centers[7] = Center{Name: "Spleen"}
-		cent.Centers[8] = Center{Name: "Emo"}
-
-	*/
-	cent.Center = make(map[string]bool, 9)
-	cent.Center["Head"] = false
-	cent.Center["Ajna"] = false
-	cent.Center["Throat"] = false
-	cent.Center["G"] = false
-	cent.Center["Sacral"] = false
-	cent.Center["Root"] = false
-	cent.Center["Ego"] = false
-	cent.Center["Spleen"] = false
-	cent.Center["Emo"] = false
-
+	cent.Center = [NUMBEROFCENTERS]bool{}
 }
 
 /*
@@ -582,6 +594,9 @@ type BspFile struct {
 	FileInfo    *FileInfo
 	NodesCoords *[]NodesJsonStruct
 	// DeltaTTable *DeltaTTable
+
+	// дополнительный SPK с малыми телами (Хирон), nil если не подключен
+	SmallBodies *SmallBodySpk
 }
 
 // [-4733494022,"north"],[-4732252235,"south"]
@@ -695,7 +710,7 @@ type PolarPosition struct {
 // months from 1 to 12
 var MonthsArr = [13]string{"", "January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
 
-// zodiac names from 0 to 11
+// zodiac names from 1 to 12
 var ZodiacNames = [13]string{
 	"",
 	"Aries",
@@ -0,0 +1,221 @@
+package cd_consts_go
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// центр бодиграфа
+type Center int
+
+// номера центров. Константы нетипизированные, как и раньше: годятся и как Center, и как int
+const (
+	HEAD = iota
+	AJNA
+	THROAT
+	G
+	SACRAL
+	ROOT
+	EGO
+	SPLEEN
+	EMO
+)
+
+// названия центров в порядке констант HEAD ... EMO, они же ключи в JSON
+var CenterNames = [NUMBEROFCENTERS]string{"Head", "Ajna", "Throat", "G", "Sacral", "Root", "Ego", "Spleen", "Emo"}
+
+func (c Center) String() string {
+	if c < 0 || c >= NUMBEROFCENTERS {
+		return "Unknown Center"
+	}
+	return CenterNames[c]
+}
+
+func (c Center) MarshalText() ([]byte, error) {
+	if c < 0 || c >= NUMBEROFCENTERS {
+		return nil, fmt.Errorf("unknown center %d", int(c))
+	}
+	return []byte(CenterNames[c]), nil
+}
+
+func (c *Center) UnmarshalText(text []byte) error {
+	center, ok := ParseCenter(string(text))
+	if !ok {
+		return fmt.Errorf("unknown center %q", text)
+	}
+	*c = center
+	return nil
+}
+
+// центр по названию из CenterNames
+func ParseCenter(name string) (Center, bool) {
+	for i, n := range CenterNames {
+		if n == name {
+			return Center(i), true
+		}
+	}
+	return 0, false
+}
+
+// в JSON Centers пишется как раньше, когда Center был map[string]bool:
+// {"Center":{"Head":false,"Ajna":true,...}}
+type centersJSON struct {
+	Center map[string]bool
+}
+
+func (cent Centers) MarshalJSON() ([]byte, error) {
+
+	m := make(map[string]bool, NUMBEROFCENTERS)
+	for i, defined := range cent.Center {
+		m[CenterNames[i]] = defined
+	}
+
+	return json.Marshal(centersJSON{Center: m})
+}
+
+func (cent *Centers) UnmarshalJSON(data []byte) error {
+
+	var cj centersJSON
+	if err := json.Unmarshal(data, &cj); err != nil {
+		return err
+	}
+
+	cent.Init()
+	for name, defined := range cj.Center {
+		c, ok := ParseCenter(name)
+		if !ok {
+			return fmt.Errorf("Centers: unknown center %q", name)
+		}
+		cent.Center[c] = defined
+	}
+
+	return nil
+}
+
+// центр каждых ворот, from 1 to 64
+var GateCenter = [NUMBEROFGATES]Center{
+	-1,
+	G,      // 1
+	G,      // 2
+	SACRAL, // 3
+	AJNA,   // 4
+	SACRAL, // 5
+	EMO,    // 6
+	G,      // 7
+	THROAT, // 8
+	SACRAL, // 9
+	G,      // 10
+	AJNA,   // 11
+	THROAT, // 12
+	G,      // 13
+	SACRAL, // 14
+	G,      // 15
+	THROAT, // 16
+	AJNA,   // 17
+	SPLEEN, // 18
+	ROOT,   // 19
+	THROAT, // 20
+	EGO,    // 21
+	EMO,    // 22
+	THROAT, // 23
+	AJNA,   // 24
+	G,      // 25
+	EGO,    // 26
+	SACRAL, // 27
+	SPLEEN, // 28
+	SACRAL, // 29
+	EMO,    // 30
+	THROAT, // 31
+	SPLEEN, // 32
+	THROAT, // 33
+	SACRAL, // 34
+	THROAT, // 35
+	EMO,    // 36
+	EMO,    // 37
+	ROOT,   // 38
+	ROOT,   // 39
+	EGO,    // 40
+	ROOT,   // 41
+	SACRAL, // 42
+	AJNA,   // 43
+	SPLEEN, // 44
+	THROAT, // 45
+	G,      // 46
+	AJNA,   // 47
+	SPLEEN, // 48
+	EMO,    // 49
+	SPLEEN, // 50
+	EGO,    // 51
+	ROOT,   // 52
+	ROOT,   // 53
+	ROOT,   // 54
+	EMO,    // 55
+	THROAT, // 56
+	SPLEEN, // 57
+	ROOT,   // 58
+	SACRAL, // 59
+	ROOT,   // 60
+	HEAD,   // 61
+	THROAT, // 62
+	HEAD,   // 63
+	HEAD,   // 64
+}
+
+func init() {
+	if err := checkChannelCenters(); err != nil {
+		panic(err)
+	}
+}
+
+// центры в ChannelTable должны совпадать с GateCenter
+func checkChannelCenters() error {
+
+	for i := 1; i < NUMBEROFCHANNELS; i++ {
+		ch := ChannelTable[i]
+		if GateCenter[ch.FirstGate] != ch.FirstCenter || GateCenter[ch.SecondGate] != ch.SecondCenter {
+			return fmt.Errorf("ChannelTable: channel %d-%d centers do not match GateCenter", ch.FirstGate, ch.SecondGate)
+		}
+	}
+
+	return nil
+}
+
+// по активированным воротам в hd.Gates определяет каналы и центры:
+// Channel.Defined и hd.Centers для общей карты, Personality.Centers и Design.Centers
+// по каналам, которые замкнуты только личностью или только дизайном
+func (hd *HdInfo) DefineCenters() {
+
+	hd.Centers.Init()
+	hd.Personality.Centers.Init()
+	hd.Design.Centers.Init()
+
+	for i := 1; i < NUMBEROFCHANNELS; i++ {
+
+		info := ChannelTable[i]
+		first := hd.Gates[info.FirstGate]
+		second := hd.Gates[info.SecondGate]
+
+		ch := &hd.Channels[i]
+		ch.Number = i
+		ch.FirstGate = first
+		ch.SecondGate = second
+		ch.Defined = first.Defined && second.Defined
+
+		if !ch.Defined {
+			continue
+		}
+
+		hd.Centers.Center[info.FirstCenter] = true
+		hd.Centers.Center[info.SecondCenter] = true
+
+		if first.Pers > 0 && second.Pers > 0 {
+			hd.Personality.Centers.Center[info.FirstCenter] = true
+			hd.Personality.Centers.Center[info.SecondCenter] = true
+		}
+
+		if first.Des > 0 && second.Des > 0 {
+			hd.Design.Centers.Center[info.FirstCenter] = true
+			hd.Design.Centers.Center[info.SecondCenter] = true
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package cd_consts_go
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// константы центров по-прежнему подходят как int, а не только как Center
+func TestCenterConstantsStayUntyped(t *testing.T) {
+
+	var asInt int = EMO
+	var asCenter Center = EMO
+
+	index := func(i int) int { return i }
+
+	if index(HEAD) != 0 || asInt != 8 || asCenter.String() != "Emo" {
+		t.Errorf("HEAD = %d, EMO = %d (%s)", index(HEAD), asInt, asCenter)
+	}
+}
+
+// JSON центров в старом виде, когда Center был map[string]bool
+const oldCentersJSON = `{"Center":{"Head":false,"Ajna":true,"Throat":true,"G":false,"Sacral":false,` +
+	`"Root":false,"Ego":false,"Spleen":false,"Emo":true}}`
+
+func TestCentersOldJSON(t *testing.T) {
+
+	want := Centers{Center: [NUMBEROFCENTERS]bool{AJNA: true, THROAT: true, EMO: true}}
+
+	var cent Centers
+	if err := json.Unmarshal([]byte(oldCentersJSON), &cent); err != nil {
+		t.Fatal(err)
+	}
+	if cent != want {
+		t.Errorf("centers %v, want %v", cent.Center, want.Center)
+	}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ключи map пишутся по алфавиту
+	if got := string(b); got != `{"Center":{"Ajna":true,"Ego":false,"Emo":true,"G":false,"Head":false,`+
+		`"Root":false,"Sacral":false,"Spleen":false,"Throat":true}}` {
+		t.Errorf("marshalled %s", got)
+	}
+
+	var hd HdInfo
+	data := `{"Centers":` + oldCentersJSON + `,"Personality":{"Centers":` + oldCentersJSON + `}}`
+	if err := json.Unmarshal([]byte(data), &hd); err != nil {
+		t.Fatal(err)
+	}
+	if hd.Centers != want || hd.Personality.Centers != want || hd.Design.Centers != (Centers{}) {
+		t.Errorf("HdInfo centers %v, personality %v, design %v", hd.Centers.Center, hd.Personality.Centers.Center, hd.Design.Centers.Center)
+	}
+
+	b, err = json.Marshal(&hd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"Centers":{"Center":{"Ajna":true,`) {
+		t.Errorf("HdInfo JSON %s has centers in a new shape", b)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Center":{"Emotional":true}}`), &cent); err == nil {
+		t.Error("want an error for an unknown center")
+	}
+}
@@ -0,0 +1,126 @@
+package cd_consts_go
+
+// контур, к которому относится канал
+type Circuit int
+
+const (
+	IntegrationCircuit Circuit = iota
+	KnowingCircuit
+	CenteringCircuit
+	UnderstandingCircuit // логический
+	SensingCircuit       // абстрактный
+	EgoCircuit
+	DefenseCircuit
+)
+
+func (c Circuit) String() string {
+	switch c {
+	case IntegrationCircuit:
+		return "Integration"
+	case KnowingCircuit:
+		return "Knowing"
+	case CenteringCircuit:
+		return "Centering"
+	case UnderstandingCircuit:
+		return "Understanding"
+	case SensingCircuit:
+		return "Sensing"
+	case EgoCircuit:
+		return "Ego"
+	case DefenseCircuit:
+		return "Defense"
+	}
+	return "Unknown Circuit"
+}
+
+// группа контуров: индивидуальный, коллективный, племенной
+type CircuitGroup int
+
+const (
+	IndividualCircuitry CircuitGroup = iota
+	CollectiveCircuitry
+	TribalCircuitry
+)
+
+func (cg CircuitGroup) String() string {
+	switch cg {
+	case IndividualCircuitry:
+		return "Individual"
+	case CollectiveCircuitry:
+		return "Collective"
+	case TribalCircuitry:
+		return "Tribal"
+	}
+	return "Unknown Circuitry"
+}
+
+func (c Circuit) Group() CircuitGroup {
+	switch c {
+	case UnderstandingCircuit, SensingCircuit:
+		return CollectiveCircuitry
+	case EgoCircuit, DefenseCircuit:
+		return TribalCircuitry
+	}
+	return IndividualCircuitry
+}
+
+// описание канала: ворота, центры этих ворот, название и контур
+type ChannelInfo struct {
+	Number       int
+	FirstGate    int
+	SecondGate   int
+	FirstCenter  Center
+	SecondCenter Center
+	Name         string
+	Circuit      Circuit
+}
+
+// 36 каналов ДЧ в той же нумерации, что и в комментарии к Channel, from 1 to 36
+var ChannelTable = [NUMBEROFCHANNELS]ChannelInfo{
+	{},
+	{1, 64, 47, HEAD, AJNA, "Abstraction", SensingCircuit},
+	{2, 61, 24, HEAD, AJNA, "Awareness", KnowingCircuit},
+	{3, 63, 4, HEAD, AJNA, "Logic", UnderstandingCircuit},
+
+	{4, 17, 62, AJNA, THROAT, "Acceptance", UnderstandingCircuit},
+	{5, 43, 23, AJNA, THROAT, "Structuring", KnowingCircuit},
+	{6, 11, 56, AJNA, THROAT, "Curiosity", SensingCircuit},
+
+	{7, 48, 16, SPLEEN, THROAT, "The Wavelength", UnderstandingCircuit},
+
+	{8, 57, 20, SPLEEN, THROAT, "The Brain Wave", IntegrationCircuit},
+	{9, 34, 20, SACRAL, THROAT, "Charisma", IntegrationCircuit},
+	{10, 10, 20, G, THROAT, "Awakening", IntegrationCircuit},
+	{11, 57, 10, SPLEEN, G, "Perfected Form", IntegrationCircuit},
+	{12, 57, 34, SPLEEN, SACRAL, "Power", IntegrationCircuit},
+	{13, 34, 10, SACRAL, G, "Exploration", CenteringCircuit},
+
+	{14, 7, 31, G, THROAT, "The Alpha", UnderstandingCircuit},
+	{15, 1, 8, G, THROAT, "Inspiration", KnowingCircuit},
+	{16, 13, 33, G, THROAT, "The Prodigal", SensingCircuit},
+	{17, 21, 45, EGO, THROAT, "Money", EgoCircuit},
+	{18, 22, 12, EMO, THROAT, "Openness", KnowingCircuit},
+	{19, 36, 35, EMO, THROAT, "Transitoriness", SensingCircuit},
+
+	{20, 5, 15, SACRAL, G, "Rhythm", UnderstandingCircuit},
+	{21, 14, 2, SACRAL, G, "The Beat", KnowingCircuit},
+	{22, 29, 46, SACRAL, G, "Discovery", SensingCircuit},
+	{23, 51, 25, EGO, G, "Initiation", CenteringCircuit},
+
+	{24, 44, 26, SPLEEN, EGO, "Surrender", EgoCircuit},
+	{25, 27, 50, SACRAL, SPLEEN, "Preservation", DefenseCircuit},
+	{26, 59, 6, SACRAL, EMO, "Mating", DefenseCircuit},
+	{27, 37, 40, EMO, EGO, "Community", EgoCircuit},
+
+	{28, 54, 32, ROOT, SPLEEN, "Transformation", EgoCircuit},
+	{29, 38, 28, ROOT, SPLEEN, "Struggle", KnowingCircuit},
+	{30, 58, 18, ROOT, SPLEEN, "Judgment", UnderstandingCircuit},
+
+	{31, 53, 42, ROOT, SACRAL, "Maturation", SensingCircuit},
+	{32, 60, 3, ROOT, SACRAL, "Mutation", KnowingCircuit},
+	{33, 52, 9, ROOT, SACRAL, "Concentration", UnderstandingCircuit},
+
+	{34, 19, 49, ROOT, EMO, "Synthesis", EgoCircuit},
+	{35, 39, 55, ROOT, EMO, "Emoting", KnowingCircuit},
+	{36, 41, 30, ROOT, EMO, "Recognition", SensingCircuit},
+}
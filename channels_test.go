@@ -0,0 +1,34 @@
+package cd_consts_go
+
+import "testing"
+
+func TestChannelsPerCircuit(t *testing.T) {
+
+	count := make(map[Circuit]int)
+	for _, ch := range ChannelTable {
+		if ch.Number == 0 {
+			continue
+		}
+		count[ch.Circuit]++
+	}
+
+	want := map[Circuit]int{
+		CenteringCircuit:     2,
+		IntegrationCircuit:   5,
+		KnowingCircuit:       8,
+		UnderstandingCircuit: 7,
+		SensingCircuit:       7,
+		EgoCircuit:           5,
+		DefenseCircuit:       2,
+	}
+
+	for c, n := range want {
+		if count[c] != n {
+			t.Errorf("%v circuit has %d channels, want %d", c, count[c], n)
+		}
+	}
+
+	if ChannelTable[13].Circuit != CenteringCircuit {
+		t.Errorf("34-10 is %v, want Centering", ChannelTable[13].Circuit)
+	}
+}
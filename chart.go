@@ -0,0 +1,65 @@
+package cd_consts_go
+
+import "errors"
+
+// окно поиска момента дизайна: Солнце проходит 88 градусов за 86 - 93 суток
+const (
+	designSearchFrom = 100 * int64(SEC_IN_1_DAY)
+	designSearchTo   = 80 * int64(SEC_IN_1_DAY)
+)
+
+// момент дизайна: Солнце на 88 градусов раньше, чем в момент рождения secFromJd2000
+func DesignTime(eph Ephemeris, secFromJd2000 int64) (int64, error) {
+
+	target := eph.Longitude(SUN, secFromJd2000) - RAD_88_DEGREES
+	if target < 0 {
+		target += 2 * PI
+	}
+
+	crossings := FindLongitudeCrossings(eph, SUN, target, secFromJd2000-designSearchFrom, secFromJd2000-designSearchTo)
+	if len(crossings) == 0 {
+		return 0, errors.New("DesignTime: Sun did not pass 88 degrees in the search window")
+	}
+
+	return crossings[len(crossings)-1], nil
+}
+
+// полная карта ДЧ на момент рождения secFromJd2000 (Ephemeries time):
+// личность, дизайн, ворота, каналы, центры, тип, авторитет, определение, профиль, крест и переменные
+func NewHdInfo(eph Ephemeris, secFromJd2000 int64) (HdInfo, error) {
+	return NewHdInfoWithOptions(eph, secFromJd2000, ChartOptions{})
+}
+
+// то же, что NewHdInfo, личность и дизайн считаются в режиме opts.Mode.
+// Момент дизайна всегда по геоцентрическому Солнцу, наблюдатель тот же, что при рождении
+func NewHdInfoWithOptions(eph Ephemeris, secFromJd2000 int64, opts ChartOptions) (HdInfo, error) {
+
+	var hd HdInfo
+
+	designSec, err := DesignTime(eph, secFromJd2000)
+	if err != nil {
+		return hd, err
+	}
+
+	hd.Init()
+
+	if hd.Personality, err = NewHdObjectsWithOptions(eph, secFromJd2000, opts); err != nil {
+		return hd, err
+	}
+	if hd.Design, err = NewHdObjectsWithOptions(eph, designSec, opts); err != nil {
+		return hd, err
+	}
+
+	hd.Derive()
+
+	return hd, nil
+}
+
+// пересчитывает все производные поля по HdStructure планет личности и дизайна
+func (hd *HdInfo) Derive() {
+	hd.ActivateGates()
+	hd.DefineCenters()
+	hd.DeriveType()
+	hd.DeriveProfileAndCross()
+	hd.DeriveVariable()
+}
@@ -0,0 +1,22 @@
+package cd_consts_go
+
+// вариант расчета карты, хранится в HdObjects.Mode
+type ChartMode int
+
+const (
+	Geocentric ChartMode = iota
+	Topocentric
+	Heliocentric
+)
+
+func (cm ChartMode) String() string {
+	switch cm {
+	case Geocentric:
+		return "Geocentric"
+	case Topocentric:
+		return "Topocentric"
+	case Heliocentric:
+		return "Heliocentric"
+	}
+	return "Unknown Chart Mode"
+}
@@ -0,0 +1,42 @@
+package cd_consts_go
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDesignTime(t *testing.T) {
+
+	day := int64(SEC_IN_1_DAY)
+
+	tests := []struct {
+		name  string
+		eph   fakeEphemeris
+		birth int64
+		want  int64 // момент, когда Солнце на 88° раньше
+	}{
+		{"linear", fakeEphemeris{start: 10, speed: 1}, 100 * day, 12 * day},
+		{"across 0", fakeEphemeris{start: 0, speed: 1}, 50 * day, -38 * day},
+	}
+
+	for _, tt := range tests {
+
+		got, err := DesignTime(tt.eph, tt.birth)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if d := got - tt.want; d < -1 || d > 1 {
+			t.Errorf("%s: design at %d, want %d", tt.name, got, tt.want)
+		}
+
+		back := angleDiff(tt.eph.Longitude(SUN, tt.birth), tt.eph.Longitude(SUN, got))
+		if math.Abs(back-RAD_88_DEGREES)*RAD_TO_DEG > 1e-4 {
+			t.Errorf("%s: Sun is %.6f° back, want 88°", tt.name, back*RAD_TO_DEG)
+		}
+	}
+
+	// за 80 ... 100 суток Солнце уходит только на 40° ... 50°
+	if _, err := DesignTime(fakeEphemeris{speed: 0.5}, 100*day); err == nil {
+		t.Error("want an error when the Sun is outside the search window")
+	}
+}
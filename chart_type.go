@@ -0,0 +1,282 @@
+package cd_consts_go
+
+import "fmt"
+
+// тип энергии, 0 - еще не рассчитан
+type EnergyType int
+
+const (
+	Manifestor EnergyType = iota + 1
+	Generator
+	ManifestingGenerator
+	Projector
+	Reflector
+)
+
+var energyTypeNames = map[EnergyType]string{
+	Manifestor:           "Manifestor",
+	Generator:            "Generator",
+	ManifestingGenerator: "Manifesting Generator",
+	Projector:            "Projector",
+	Reflector:            "Reflector",
+}
+
+func (et EnergyType) String() string {
+	return energyTypeNames[et]
+}
+
+// в JSON пишется названием, как раньше строкой
+func (et EnergyType) MarshalText() ([]byte, error) {
+	return []byte(et.String()), nil
+}
+
+func (et *EnergyType) UnmarshalText(text []byte) error {
+	return unmarshalLabel(text, energyTypeNames, et, "energy type")
+}
+
+// авторитет, 0 - еще не рассчитан
+type Authority int
+
+const (
+	EmotionalAuthority Authority = iota + 1
+	SacralAuthority
+	SplenicAuthority
+	EgoManifestedAuthority
+	EgoProjectedAuthority
+	SelfProjectedAuthority
+	MentalAuthority // у проекторов без внутреннего авторитета (environmental)
+	LunarAuthority
+)
+
+var authorityNames = map[Authority]string{
+	EmotionalAuthority:     "Emotional",
+	SacralAuthority:        "Sacral",
+	SplenicAuthority:       "Splenic",
+	EgoManifestedAuthority: "Ego Manifested",
+	EgoProjectedAuthority:  "Ego Projected",
+	SelfProjectedAuthority: "Self-Projected",
+	MentalAuthority:        "Mental",
+	LunarAuthority:         "Lunar",
+}
+
+func (a Authority) String() string {
+	return authorityNames[a]
+}
+
+func (a Authority) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+func (a *Authority) UnmarshalText(text []byte) error {
+	return unmarshalLabel(text, authorityNames, a, "authority")
+}
+
+// определение - число связных групп определенных центров, 0 - еще не рассчитано
+type Definition int
+
+const (
+	NoDefinition Definition = iota + 1
+	SingleDefinition
+	SplitDefinition
+	TripleSplitDefinition
+	QuadrupleSplitDefinition
+)
+
+var definitionNames = map[Definition]string{
+	NoDefinition:             "No Definition",
+	SingleDefinition:         "Single Definition",
+	SplitDefinition:          "Split Definition",
+	TripleSplitDefinition:    "Triple Split Definition",
+	QuadrupleSplitDefinition: "Quadruple Split Definition",
+}
+
+func (d Definition) String() string {
+	return definitionNames[d]
+}
+
+func (d Definition) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Definition) UnmarshalText(text []byte) error {
+	return unmarshalLabel(text, definitionNames, d, "definition")
+}
+
+func unmarshalLabel[T comparable](text []byte, names map[T]string, v *T, what string) error {
+
+	var zero T
+	if len(text) == 0 {
+		*v = zero
+		return nil
+	}
+
+	for k, name := range names {
+		if name == string(text) {
+			*v = k
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown %s %q", what, text)
+}
+
+// граф центров, ребра - определенные каналы
+type centerGraph struct {
+	defined [NUMBEROFCENTERS]bool
+	linked  [NUMBEROFCENTERS][NUMBEROFCENTERS]bool
+}
+
+func newCenterGraph(channels []int) *centerGraph {
+
+	var cg centerGraph
+	for _, ch := range channels {
+		a, b := ChannelTable[ch].FirstCenter, ChannelTable[ch].SecondCenter
+		cg.defined[a], cg.defined[b] = true, true
+		cg.linked[a][b], cg.linked[b][a] = true, true
+	}
+
+	return &cg
+}
+
+// номер связной группы для каждого определенного центра (-1 для неопределенных) и число групп
+func (cg *centerGraph) components() ([NUMBEROFCENTERS]int, int) {
+
+	var comp [NUMBEROFCENTERS]int
+	for i := range comp {
+		comp[i] = -1
+	}
+
+	count := 0
+	for start := 0; start < NUMBEROFCENTERS; start++ {
+
+		if !cg.defined[start] || comp[start] >= 0 {
+			continue
+		}
+
+		stack := []int{start}
+		comp[start] = count
+		for len(stack) > 0 {
+			c := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for next := 0; next < NUMBEROFCENTERS; next++ {
+				if cg.linked[c][next] && comp[next] < 0 {
+					comp[next] = count
+					stack = append(stack, next)
+				}
+			}
+		}
+		count++
+	}
+
+	return comp, count
+}
+
+func (cg *centerGraph) connected(a, b Center) bool {
+	comp, _ := cg.components()
+	return comp[a] >= 0 && comp[a] == comp[b]
+}
+
+// есть ли путь от мотора (сакрал, корень, эго, эмоциональный) к горлу
+func (cg *centerGraph) motorToThroat() bool {
+
+	for _, motor := range []Center{SACRAL, ROOT, EGO, EMO} {
+		if cg.connected(motor, THROAT) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (cg *centerGraph) energyType() EnergyType {
+
+	_, count := cg.components()
+
+	switch {
+	case count == 0:
+		return Reflector
+	case cg.defined[SACRAL] && cg.motorToThroat():
+		return ManifestingGenerator
+	case cg.defined[SACRAL]:
+		return Generator
+	case cg.motorToThroat():
+		return Manifestor
+	}
+
+	return Projector
+}
+
+// авторитет по старшинству: эмоциональный, сакральный, селезеночный, эго, G, ментальный, лунный
+func (cg *centerGraph) authority() Authority {
+
+	switch {
+	case cg.defined[EMO]:
+		return EmotionalAuthority
+	case cg.defined[SACRAL]:
+		return SacralAuthority
+	case cg.defined[SPLEEN]:
+		return SplenicAuthority
+	case cg.defined[EGO] && cg.connected(EGO, THROAT):
+		return EgoManifestedAuthority
+	case cg.defined[EGO]:
+		return EgoProjectedAuthority
+	case cg.defined[G] && cg.connected(G, THROAT):
+		return SelfProjectedAuthority
+	}
+
+	if _, count := cg.components(); count > 0 {
+		return MentalAuthority
+	}
+
+	return LunarAuthority
+}
+
+func (cg *centerGraph) definition() Definition {
+
+	_, count := cg.components()
+
+	d := NoDefinition + Definition(count)
+	if d > QuadrupleSplitDefinition {
+		d = QuadrupleSplitDefinition
+	}
+
+	return d
+}
+
+// определенные каналы общей карты, личности и дизайна, hd.Channels должны быть уже рассчитаны
+func (hd *HdInfo) definedChannels() (all, pers, des []int) {
+
+	for i := 1; i < NUMBEROFCHANNELS; i++ {
+
+		ch := hd.Channels[i]
+		if !ch.Defined {
+			continue
+		}
+
+		all = append(all, i)
+		if ch.FirstGate.Pers > 0 && ch.SecondGate.Pers > 0 {
+			pers = append(pers, i)
+		}
+		if ch.FirstGate.Des > 0 && ch.SecondGate.Des > 0 {
+			des = append(des, i)
+		}
+	}
+
+	return all, pers, des
+}
+
+// заполняет Type, Authority и Definition по графу центров и каналов,
+// Authority личности и дизайна считается по их собственным каналам.
+// Вызывается после DefineCenters
+func (hd *HdInfo) DeriveType() {
+
+	all, pers, des := hd.definedChannels()
+
+	cg := newCenterGraph(all)
+	hd.Type = cg.energyType()
+	hd.Authority = cg.authority()
+	hd.Definition = cg.definition()
+
+	hd.Personality.Authority = newCenterGraph(pers).authority()
+	hd.Design.Authority = newCenterGraph(des).authority()
+}
@@ -0,0 +1,107 @@
+package cd_consts_go
+
+import "testing"
+
+func TestDeriveType(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		gates     []int
+		typ       EnergyType
+		authority Authority
+	}{
+		{"reflector", nil, Reflector, LunarAuthority},
+		{"generator 5-15", []int{5, 15}, Generator, SacralAuthority},
+		{"manifesting generator 34-20", []int{34, 20}, ManifestingGenerator, SacralAuthority},
+		{"manifestor 21-45", []int{21, 45}, Manifestor, EgoManifestedAuthority},
+		{"projector 7-31", []int{7, 31}, Projector, SelfProjectedAuthority},
+		{"emotional 59-6", []int{59, 6}, Generator, EmotionalAuthority},
+		{"splenic 57-10", []int{57, 10}, Projector, SplenicAuthority},
+		{"ego projected 25-51", []int{25, 51}, Projector, EgoProjectedAuthority},
+		{"mental 64-47", []int{64, 47}, Projector, MentalAuthority},
+		{"emotional over sacral 59-6 34-20", []int{59, 6, 34, 20}, ManifestingGenerator, EmotionalAuthority},
+	}
+
+	for _, tt := range tests {
+		hd := chartWithGates(tt.gates...)
+		hd.DeriveType()
+
+		if hd.Type != tt.typ {
+			t.Errorf("%s: type %v, want %v", tt.name, hd.Type, tt.typ)
+		}
+		if hd.Authority != tt.authority {
+			t.Errorf("%s: authority %v, want %v", tt.name, hd.Authority, tt.authority)
+		}
+	}
+}
+
+func TestDeriveTypeDefinition(t *testing.T) {
+
+	tests := []struct {
+		gates      []int
+		definition Definition
+	}{
+		{nil, NoDefinition},
+		{[]int{64, 47}, SingleDefinition},
+		{[]int{64, 47, 17, 62, 7, 31}, SingleDefinition},
+		{[]int{64, 47, 5, 15}, SplitDefinition},
+		{[]int{64, 47, 5, 15, 19, 49}, TripleSplitDefinition},
+		{[]int{64, 47, 5, 15, 19, 49, 44, 26}, QuadrupleSplitDefinition},
+	}
+
+	for _, tt := range tests {
+		hd := chartWithGates(tt.gates...)
+		hd.DeriveType()
+
+		if hd.Definition != tt.definition {
+			t.Errorf("gates %v: %v, want %v", tt.gates, hd.Definition, tt.definition)
+		}
+	}
+
+	var hd HdInfo
+	if hd.Definition != 0 || hd.Definition.String() != "" {
+		t.Errorf("zero Definition is %q, want not calculated", hd.Definition)
+	}
+}
+
+func TestDeriveTypeSides(t *testing.T) {
+
+	tests := []struct {
+		name             string
+		pers, des        []int
+		all, pers1, des1 Authority
+	}{
+		{"7-31 personality, 59-6 design", []int{7, 31}, []int{59, 6},
+			EmotionalAuthority, SelfProjectedAuthority, EmotionalAuthority},
+		{"21 personality, 45 design", []int{21}, []int{45},
+			EgoManifestedAuthority, LunarAuthority, LunarAuthority},
+		{"57-10 both sides", []int{57, 10}, []int{57, 10},
+			SplenicAuthority, SplenicAuthority, SplenicAuthority},
+	}
+
+	for _, tt := range tests {
+
+		var hd HdInfo
+		hd.Init()
+		for _, g := range tt.pers {
+			hd.Gates[g].Pers = 1
+			hd.Gates[g].Defined = true
+		}
+		for _, g := range tt.des {
+			hd.Gates[g].Des = 1
+			hd.Gates[g].Defined = true
+		}
+		hd.DefineCenters()
+		hd.DeriveType()
+
+		if hd.Authority != tt.all {
+			t.Errorf("%s: authority %v, want %v", tt.name, hd.Authority, tt.all)
+		}
+		if hd.Personality.Authority != tt.pers1 {
+			t.Errorf("%s: personality authority %v, want %v", tt.name, hd.Personality.Authority, tt.pers1)
+		}
+		if hd.Design.Authority != tt.des1 {
+			t.Errorf("%s: design authority %v, want %v", tt.name, hd.Design.Authority, tt.des1)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package cd_consts_go
+
+// как канал образуется в композите двух карт. 0 - канала в композите нет
+type ConnectionKind int
+
+const (
+	Electromagnetic ConnectionKind = iota + 1 // у каждого по одним воротам канала
+	Companionship                             // канал целиком у обоих
+	Dominance                                 // канал целиком у одного, у другого нет его ворот
+	Compromise                                // канал целиком у одного, у другого только одни его ворота
+)
+
+func (ck ConnectionKind) String() string {
+	switch ck {
+	case Electromagnetic:
+		return "Electromagnetic"
+	case Companionship:
+		return "Companionship"
+	case Dominance:
+		return "Dominance"
+	case Compromise:
+		return "Compromise"
+	}
+	return ""
+}
+
+type ChannelConnection struct {
+	Channel int // номер в ChannelTable
+	Kind    ConnectionKind
+
+	// для Dominance и Compromise: 1 - канал целиком у первой карты, 2 - у второй
+	Owner int
+}
+
+// композит двух карт: объединенные ворота, каналы, центры, тип и определение
+// плюс вид каждого канала, определенного в композите
+type Composite struct {
+	HdInfo
+	Connections []ChannelConnection
+}
+
+// вид канала ch между картами a и b, ворота карт должны быть уже активированы (ActivateGates)
+func ConnectionOf(a, b *HdInfo, ch int) ChannelConnection {
+
+	info := ChannelTable[ch]
+
+	a1, a2 := a.Gates[info.FirstGate].Defined, a.Gates[info.SecondGate].Defined
+	b1, b2 := b.Gates[info.FirstGate].Defined, b.Gates[info.SecondGate].Defined
+
+	aFull, bFull := a1 && a2, b1 && b2
+
+	conn := ChannelConnection{Channel: ch}
+
+	switch {
+	case aFull && bFull:
+		conn.Kind = Companionship
+	case aFull:
+		conn.Owner = 1
+		conn.Kind = Dominance
+		if b1 || b2 {
+			conn.Kind = Compromise
+		}
+	case bFull:
+		conn.Owner = 2
+		conn.Kind = Dominance
+		if a1 || a2 {
+			conn.Kind = Compromise
+		}
+	case (a1 && b2) || (a2 && b1):
+		conn.Kind = Electromagnetic
+	}
+
+	return conn
+}
+
+// композит карт a и b. Pers и Des ворот складываются,
+// так что Personality/Design центры композита - общие для личностей и для дизайнов обоих
+func NewComposite(a, b *HdInfo) Composite {
+
+	var comp Composite
+	comp.Init()
+
+	for i := 1; i < NUMBEROFGATES; i++ {
+		comp.Gates[i].Pers = a.Gates[i].Pers + b.Gates[i].Pers
+		comp.Gates[i].Des = a.Gates[i].Des + b.Gates[i].Des
+		comp.Gates[i].Defined = a.Gates[i].Defined || b.Gates[i].Defined
+	}
+
+	comp.DefineCenters()
+	comp.DeriveType()
+
+	for ch := 1; ch < NUMBEROFCHANNELS; ch++ {
+		if conn := ConnectionOf(a, b, ch); conn.Kind != 0 {
+			comp.Connections = append(comp.Connections, conn)
+		}
+	}
+
+	return comp
+}
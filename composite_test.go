@@ -0,0 +1,51 @@
+package cd_consts_go
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewComposite(t *testing.T) {
+
+	// 64-47 и 34-20 на двоих, 7-31 и 59-6 у первого, 1-8 и 21-45 у второго
+	a := chartWithGates(64, 34, 20, 7, 31, 59, 6, 21)
+	b := chartWithGates(47, 34, 20, 1, 8, 6, 21, 45)
+
+	comp := NewComposite(a, b)
+
+	want := []ChannelConnection{
+		{Channel: 1, Kind: Electromagnetic},
+		{Channel: 9, Kind: Companionship},
+		{Channel: 14, Kind: Dominance, Owner: 1},
+		{Channel: 15, Kind: Dominance, Owner: 2},
+		{Channel: 17, Kind: Compromise, Owner: 2},
+		{Channel: 26, Kind: Compromise, Owner: 1},
+	}
+	if !reflect.DeepEqual(comp.Connections, want) {
+		t.Errorf("connections %+v, want %+v", comp.Connections, want)
+	}
+
+	for _, tt := range want {
+		if got := ConnectionOf(a, b, tt.Channel); got != tt {
+			t.Errorf("ConnectionOf(%d) = %+v, want %+v", tt.Channel, got, tt)
+		}
+	}
+	if got := ConnectionOf(a, b, 2); got.Kind != 0 || got.Owner != 0 {
+		t.Errorf("ConnectionOf(2) = %+v, want no connection", got)
+	}
+
+	defined := [NUMBEROFCENTERS]bool{
+		HEAD: true, AJNA: true, THROAT: true, G: true, SACRAL: true, EGO: true, EMO: true,
+	}
+	if comp.Centers.Center != defined {
+		t.Errorf("centers %v, want %v", comp.Centers.Center, defined)
+	}
+
+	if comp.Gates[34].Pers != 2 || !comp.Gates[47].Defined {
+		t.Errorf("gates 34 %+v, 47 %+v", comp.Gates[34], comp.Gates[47])
+	}
+
+	if comp.Type != ManifestingGenerator || comp.Authority != EmotionalAuthority || comp.Definition != SplitDefinition {
+		t.Errorf("composite %v, %v, %v", comp.Type, comp.Authority, comp.Definition)
+	}
+}
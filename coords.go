@@ -0,0 +1,58 @@
+package cd_consts_go
+
+import "math"
+
+// разность двух векторов (положение и скорость), например тело относительно Солнца
+func (p Position) Sub(other Position) Position {
+	return Position{
+		X: p.X - other.X,
+		Y: p.Y - other.Y,
+		Z: p.Z - other.Z,
+
+		VelocityX: p.VelocityX - other.VelocityX,
+		VelocityY: p.VelocityY - other.VelocityY,
+		VelocityZ: p.VelocityZ - other.VelocityZ,
+	}
+}
+
+// поворот экваториальных координат (как в SPK) в эклиптические на угол eps (обычно MED_EPS)
+func EquatorialToEcliptic(p Position, eps float64) Position {
+
+	sinEps, cosEps := math.Sincos(eps)
+
+	return Position{
+		X: p.X,
+		Y: p.Y*cosEps + p.Z*sinEps,
+		Z: -p.Y*sinEps + p.Z*cosEps,
+
+		VelocityX: p.VelocityX,
+		VelocityY: p.VelocityY*cosEps + p.VelocityZ*sinEps,
+		VelocityZ: -p.VelocityY*sinEps + p.VelocityZ*cosEps,
+	}
+}
+
+// перевод в полярные координаты, долгота от 0 до 2*PI, скорости копируются как есть
+func (p Position) Polar() PolarPosition {
+
+	lon := math.Atan2(p.Y, p.X)
+	if lon < 0 {
+		lon += 2 * PI
+	}
+
+	radius := math.Sqrt(p.X*p.X + p.Y*p.Y + p.Z*p.Z)
+
+	var lat float64
+	if radius > 0 {
+		lat = math.Asin(p.Z / radius)
+	}
+
+	return PolarPosition{
+		Longitude: lon,
+		Latitude:  lat,
+		Radius:    radius,
+
+		VelocityX: p.VelocityX,
+		VelocityY: p.VelocityY,
+		VelocityZ: p.VelocityZ,
+	}
+}
@@ -0,0 +1,201 @@
+package cd_consts_go
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// угол креста, определяется профилем. 0 - не рассчитан
+type CrossAngle int
+
+const (
+	RightAngle CrossAngle = iota + 1
+	Juxtaposition
+	LeftAngle
+)
+
+func (ca CrossAngle) String() string {
+	switch ca {
+	case RightAngle:
+		return "Right Angle"
+	case Juxtaposition:
+		return "Juxtaposition"
+	case LeftAngle:
+		return "Left Angle"
+	}
+	return ""
+}
+
+// угол по линиям Солнца личности и дизайна: 1/3 ... 4/6 правый, 4/1 джакстапозиция, 5/1 ... 6/3 левый
+func ProfileAngle(persLine, desLine int) CrossAngle {
+
+	switch [2]int{persLine, desLine} {
+	case [2]int{1, 3}, [2]int{1, 4}, [2]int{2, 4}, [2]int{2, 5}, [2]int{3, 5}, [2]int{3, 6}, [2]int{4, 6}:
+		return RightAngle
+	case [2]int{4, 1}:
+		return Juxtaposition
+	case [2]int{5, 1}, [2]int{5, 2}, [2]int{6, 2}, [2]int{6, 3}:
+		return LeftAngle
+	}
+
+	return 0
+}
+
+// правоугольные кресты: 16 названий, у каждого 4 ворот Солнца личности
+var rightAngleCrosses = []struct {
+	Name  string
+	Gates [4]int
+}{
+	{"the Vessel of Love", [4]int{25, 46, 10, 15}},
+	{"Service", [4]int{17, 18, 58, 52}},
+	{"Tension", [4]int{21, 48, 38, 39}},
+	{"Penetration", [4]int{51, 57, 54, 53}},
+	{"Maya", [4]int{42, 32, 61, 62}},
+	{"Laws", [4]int{3, 50, 60, 56}},
+	{"the Unexpected", [4]int{27, 28, 41, 31}},
+	{"the Four Ways", [4]int{24, 44, 19, 33}},
+	{"the Sphinx", [4]int{2, 1, 13, 7}},
+	{"Explanation", [4]int{23, 43, 49, 4}},
+	{"Contagion", [4]int{8, 14, 30, 29}},
+	{"the Sleeping Phoenix", [4]int{20, 34, 55, 59}},
+	{"Planning", [4]int{16, 9, 37, 40}},
+	{"Consciousness", [4]int{35, 5, 63, 64}},
+	{"Rulership", [4]int{45, 26, 47, 22}},
+	{"Eden", [4]int{12, 11, 36, 6}},
+}
+
+// левоугольные кресты: 32 названия, у каждого 2 противоположных ворот Солнца личности
+var leftAngleCrosses = []struct {
+	Name  string
+	Gates [2]int
+}{
+	{"Healing", [2]int{25, 46}},
+	{"Upheaval", [2]int{17, 18}},
+	{"Endeavour", [2]int{21, 48}},
+	{"the Clarion", [2]int{51, 57}},
+	{"Limitation", [2]int{42, 32}},
+	{"Wishes", [2]int{3, 50}},
+	{"Alignment", [2]int{27, 28}},
+	{"Incarnation", [2]int{24, 44}},
+	{"Defiance", [2]int{2, 1}},
+	{"Dedication", [2]int{23, 43}},
+	{"Uncertainty", [2]int{8, 14}},
+	{"Duality", [2]int{20, 34}},
+	{"Identification", [2]int{16, 9}},
+	{"Separation", [2]int{35, 5}},
+	{"Confrontation", [2]int{45, 26}},
+	{"Education", [2]int{12, 11}},
+	{"Prevention", [2]int{15, 10}},
+	{"Demands", [2]int{52, 58}},
+	{"Individualism", [2]int{39, 38}},
+	{"Cycles", [2]int{53, 54}},
+	{"Obscuration", [2]int{62, 61}},
+	{"Distraction", [2]int{56, 60}},
+	{"the Alpha", [2]int{31, 41}},
+	{"Refinement", [2]int{33, 19}},
+	{"Masks", [2]int{7, 13}},
+	{"Revolution", [2]int{4, 49}},
+	{"Industry", [2]int{29, 30}},
+	{"Spirit", [2]int{59, 55}},
+	{"Migration", [2]int{40, 37}},
+	{"Dominion", [2]int{64, 63}},
+	{"Informing", [2]int{47, 22}},
+	{"the Plane", [2]int{6, 36}},
+}
+
+// кресты джакстапозиции: свое название у каждых ворот Солнца личности, from 1 to 64
+var juxtapositionCrosses = [NUMBEROFGATES]string{
+	"",
+	"Self-Expression", "the Driver", "Mutation", "Formulization", "Habits", "Conflict", "Interaction", "Contribution",
+	"Focus", "Behavior", "Ideas", "Articulation", "Listening", "Empowering", "Extremes", "Experimentation",
+	"Opinions", "Correction", "Need", "the Now", "Control", "Grace", "Assimilation", "Rationalization",
+	"Innocence", "the Trickster", "Caring", "Risks", "Commitment", "Fates", "Influence", "Conservation",
+	"Retreat", "Power", "Experience", "Crisis", "Bargains", "Opposition", "Provocation", "Denial",
+	"Fantasy", "Completion", "Insight", "Alertness", "Possession", "Serendipity", "Oppression", "Depth",
+	"Principles", "Values", "Shock", "Stillness", "Beginnings", "Ambition", "Moods", "Stimulation",
+	"Intuition", "Vitality", "Strategy", "Limitation", "Thinking", "Detail", "Doubts", "Confusion",
+}
+
+// все 192 креста: угол x ворота Солнца личности -> название
+var CrossNames [LeftAngle + 1][NUMBEROFGATES]string
+
+func init() {
+
+	for _, rac := range rightAngleCrosses {
+		for _, gate := range rac.Gates {
+			CrossNames[RightAngle][gate] = "Right Angle Cross of " + rac.Name
+		}
+	}
+
+	for gate := 1; gate < NUMBEROFGATES; gate++ {
+		CrossNames[Juxtaposition][gate] = "Juxtaposition Cross of " + juxtapositionCrosses[gate]
+	}
+
+	for _, lax := range leftAngleCrosses {
+		for _, gate := range lax.Gates {
+			CrossNames[LeftAngle][gate] = "Left Angle Cross of " + lax.Name
+		}
+	}
+
+	if err := checkCrossNames(); err != nil {
+		panic(err)
+	}
+}
+
+func checkCrossNames() error {
+
+	for angle := RightAngle; angle <= LeftAngle; angle++ {
+		for gate := 1; gate < NUMBEROFGATES; gate++ {
+			if CrossNames[angle][gate] == "" {
+				return fmt.Errorf("CrossNames: no %s cross for gate %d", angle, gate)
+			}
+		}
+	}
+
+	return nil
+}
+
+// название креста по углу и воротам Солнца личности
+func CrossName(angle CrossAngle, persSunGate int) string {
+
+	if angle < RightAngle || angle > LeftAngle || persSunGate < 1 || persSunGate >= NUMBEROFGATES {
+		return ""
+	}
+
+	return CrossNames[angle][persSunGate]
+}
+
+func (c Cross) String() string {
+
+	if c.Name == "" {
+		return ""
+	}
+
+	return c.Name + " (" + strconv.Itoa(c.First) + "/" + strconv.Itoa(c.Second) + " | " +
+		strconv.Itoa(c.Third) + "/" + strconv.Itoa(c.Forth) + ")"
+}
+
+// заполняет Profile и Cross по Солнцу и Земле личности и дизайна,
+// HdStructure планет должны быть уже рассчитаны
+func (hd *HdInfo) DeriveProfileAndCross() {
+
+	pSun := hd.Personality.Planet[SUN].HdStructure
+	dSun := hd.Design.Planet[SUN].HdStructure
+
+	persLine := int(math.Ceil(pSun.Line))
+	desLine := int(math.Ceil(dSun.Line))
+
+	hd.Profile = strconv.Itoa(persLine) + "/" + strconv.Itoa(desLine)
+
+	angle := ProfileAngle(persLine, desLine)
+
+	hd.Cross = Cross{
+		First:  pSun.Hex,
+		Second: hd.Personality.Planet[EARTH].Hex,
+		Third:  dSun.Hex,
+		Forth:  hd.Design.Planet[EARTH].Hex,
+		Angle:  angle,
+		Name:   CrossName(angle, pSun.Hex),
+	}
+}
@@ -0,0 +1,86 @@
+package cd_consts_go
+
+import "testing"
+
+func TestProfileAngle(t *testing.T) {
+
+	tests := []struct {
+		pers, des int
+		angle     CrossAngle
+	}{
+		{1, 3, RightAngle},
+		{1, 4, RightAngle},
+		{2, 4, RightAngle},
+		{2, 5, RightAngle},
+		{3, 5, RightAngle},
+		{3, 6, RightAngle},
+		{4, 6, RightAngle},
+		{4, 1, Juxtaposition},
+		{5, 1, LeftAngle},
+		{5, 2, LeftAngle},
+		{6, 2, LeftAngle},
+		{6, 3, LeftAngle},
+		{1, 1, 0},
+		{0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := ProfileAngle(tt.pers, tt.des); got != tt.angle {
+			t.Errorf("ProfileAngle(%d, %d) = %v, want %v", tt.pers, tt.des, got, tt.angle)
+		}
+	}
+}
+
+func TestCrossName(t *testing.T) {
+
+	tests := []struct {
+		angle CrossAngle
+		gate  int
+		name  string
+	}{
+		{RightAngle, 1, "Right Angle Cross of the Sphinx"},
+		{RightAngle, 2, "Right Angle Cross of the Sphinx"},
+		{RightAngle, 7, "Right Angle Cross of the Sphinx"},
+		{RightAngle, 13, "Right Angle Cross of the Sphinx"},
+		{Juxtaposition, 1, "Juxtaposition Cross of Self-Expression"},
+		{LeftAngle, 1, "Left Angle Cross of Defiance"},
+		{LeftAngle, 2, "Left Angle Cross of Defiance"},
+		{0, 1, ""},
+		{RightAngle, 0, ""},
+		{RightAngle, NUMBEROFGATES, ""},
+	}
+
+	for _, tt := range tests {
+		if got := CrossName(tt.angle, tt.gate); got != tt.name {
+			t.Errorf("CrossName(%v, %d) = %q, want %q", tt.angle, tt.gate, got, tt.name)
+		}
+	}
+}
+
+func TestDeriveProfileAndCross(t *testing.T) {
+
+	var hd HdInfo
+	hd.Init()
+	hd.Personality.Planet[SUN].HdStructure = HdStructure{Hex: 13, Line: 0.5}
+	hd.Personality.Planet[EARTH].HdStructure = HdStructure{Hex: 7, Line: 0.5}
+	hd.Design.Planet[SUN].HdStructure = HdStructure{Hex: 2, Line: 2.3}
+	hd.Design.Planet[EARTH].HdStructure = HdStructure{Hex: 1, Line: 2.3}
+
+	hd.DeriveProfileAndCross()
+
+	if hd.Profile != "1/3" {
+		t.Errorf("profile %q, want 1/3", hd.Profile)
+	}
+
+	want := Cross{
+		First:  13,
+		Second: 7,
+		Third:  2,
+		Forth:  1,
+		Angle:  RightAngle,
+		Name:   "Right Angle Cross of the Sphinx",
+	}
+	if hd.Cross != want {
+		t.Errorf("cross %+v, want %+v", hd.Cross, want)
+	}
+}
@@ -0,0 +1,84 @@
+package cd_consts_go
+
+import "math"
+
+// жизненные циклы, которые отмечают в чтениях ДЧ
+type CycleKind int
+
+const (
+	SaturnReturn CycleKind = iota + 1
+	SecondSaturnReturn
+	UranianOpposition
+	HironReturn
+)
+
+func (ck CycleKind) String() string {
+	switch ck {
+	case SaturnReturn:
+		return "Saturn Return"
+	case SecondSaturnReturn:
+		return "Second Saturn Return"
+	case UranianOpposition:
+		return "Uranian Opposition"
+	case HironReturn:
+		return "Chiron Return"
+	}
+	return ""
+}
+
+const secInJulianYear = 365.25 * float64(SEC_IN_1_DAY)
+
+// тело, смещение от натальной долготы и окно поиска в годах после рождения
+var cycleRules = []struct {
+	Kind     CycleKind
+	Body     int
+	Offset   float64
+	FromYear float64
+	ToYear   float64
+}{
+	{SaturnReturn, SATURN, 0, 26, 33},
+	{SecondSaturnReturn, SATURN, 0, 55, 63},
+	{UranianOpposition, URANUS, PI, 36, 46},
+	{HironReturn, HIRON, 0, 45, 55},
+}
+
+// один точный проход; из-за попятного движения у цикла их может быть 1 или 3
+type CyclePass struct {
+	Kind          CycleKind
+	Pass          int // from 1
+	SecFromJd2000 int64
+	Chart         HdObjects // небо на момент прохода
+}
+
+// точные даты циклов для рождения birth (используется birth.SecFromJd2000).
+// Возврат Хирона ищется, только если эфемериды его поддерживают (HironSupport)
+func LifeCycles(eph Ephemeris, birth TimeData) []CyclePass {
+
+	var passes []CyclePass
+
+	hs, ok := eph.(HironSupport)
+	withHiron := ok && hs.HasHiron()
+
+	for _, rule := range cycleRules {
+
+		if rule.Body == HIRON && !withHiron {
+			continue
+		}
+
+		target := math.Mod(eph.Longitude(rule.Body, birth.SecFromJd2000)+rule.Offset, 2*PI)
+
+		from := birth.SecFromJd2000 + int64(rule.FromYear*secInJulianYear)
+		to := birth.SecFromJd2000 + int64(rule.ToYear*secInJulianYear)
+
+		for i, sec := range FindLongitudeCrossings(eph, rule.Body, target, from, to) {
+			passes = append(passes, CyclePass{
+				Kind:          rule.Kind,
+				Pass:          i + 1,
+				SecFromJd2000: sec,
+				Chart:         NewHdObjects(eph, sec),
+			})
+		}
+	}
+
+	return passes
+}
@@ -0,0 +1,81 @@
+package cd_consts_go
+
+import "math"
+
+// Дельта T = TT - UT в секундах для дробного года,
+// полиномы Espenak & Meeus, https://eclipse.gsfc.nasa.gov/SEhelp/deltatpoly2004.html
+func DeltaT(year float64) float64 {
+
+	y := year
+
+	switch {
+	case y < -500:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u
+
+	case y < 500:
+		u := y / 100
+		return 10583.6 - 1014.41*u + 33.78311*math.Pow(u, 2) - 5.952053*math.Pow(u, 3) -
+			0.1798452*math.Pow(u, 4) + 0.022174192*math.Pow(u, 5) + 0.0090316521*math.Pow(u, 6)
+
+	case y < 1600:
+		u := (y - 1000) / 100
+		return 1574.2 - 556.01*u + 71.23472*math.Pow(u, 2) + 0.319781*math.Pow(u, 3) -
+			0.8503463*math.Pow(u, 4) - 0.005050998*math.Pow(u, 5) + 0.0083572073*math.Pow(u, 6)
+
+	case y < 1700:
+		t := y - 1600
+		return 120 - 0.9808*t - 0.01532*t*t + math.Pow(t, 3)/7129
+
+	case y < 1800:
+		t := y - 1700
+		return 8.83 + 0.1603*t - 0.0059285*t*t + 0.00013336*math.Pow(t, 3) - math.Pow(t, 4)/1174000
+
+	case y < 1860:
+		t := y - 1800
+		return 13.72 - 0.332447*t + 0.0068612*t*t + 0.0041116*math.Pow(t, 3) - 0.00037436*math.Pow(t, 4) +
+			0.0000121272*math.Pow(t, 5) - 0.0000001699*math.Pow(t, 6) + 0.000000000875*math.Pow(t, 7)
+
+	case y < 1900:
+		t := y - 1860
+		return 7.62 + 0.5737*t - 0.251754*t*t + 0.01680668*math.Pow(t, 3) -
+			0.0004473624*math.Pow(t, 4) + math.Pow(t, 5)/233174
+
+	case y < 1920:
+		t := y - 1900
+		return -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*math.Pow(t, 3) - 0.000197*math.Pow(t, 4)
+
+	case y < 1941:
+		t := y - 1920
+		return 21.20 + 0.84493*t - 0.076100*t*t + 0.0020936*math.Pow(t, 3)
+
+	case y < 1961:
+		t := y - 1950
+		return 29.07 + 0.407*t - t*t/233 + math.Pow(t, 3)/2547
+
+	case y < 1986:
+		t := y - 1975
+		return 45.45 + 1.067*t - t*t/260 - math.Pow(t, 3)/718
+
+	case y < 2005:
+		t := y - 2000
+		return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*math.Pow(t, 3) +
+			0.000651814*math.Pow(t, 4) + 0.00002373599*math.Pow(t, 5)
+
+	case y < 2050:
+		t := y - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+
+	case y < 2150:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-y)
+	}
+
+	u := (y - 1820) / 100
+	return -20 + 32*u*u
+}
+
+// Дельта T для момента в секундах от JD2000 (Ephemeries time)
+func DeltaTForSec(secFromJd2000 int64) float64 {
+	return DeltaT(2000.0 + float64(secFromJd2000)/(365.25*float64(SEC_IN_1_DAY)))
+}
@@ -0,0 +1,98 @@
+package cd_consts_go
+
+import "math"
+
+// значения FdStructure.Direction
+const (
+	DIRECT     = "D"
+	RETROGRADE = "R"
+	STATIONARY = "S"
+)
+
+// порог стационарности для каждого тела в градусах в сутки,
+// если модуль скорости по долготе меньше порога - планета стационарна.
+// 0 - тело никогда не считается стационарным (Солнце, Луна, Земля, узлы)
+var StationaryThreshold = [NUMBEROFPLANETSWITHHIRON]float64{
+	SSB:     0,
+	MERCURY: 0.1,
+	VENUS:   0.06,
+	EARTH:   0,
+	MARS:    0.03,
+	JUPITER: 0.01,
+	SATURN:  0.007,
+	URANUS:  0.003,
+	NEPTUNE: 0.002,
+	PLUTO:   0.002,
+	SUN:     0,
+	MOON:    0,
+	// узлы движутся ретроградно, короткие прямые участки истинного узла не выделяем
+	NORTHNODE: 0,
+	SOUTHNODE: 0,
+	HIRON:     0.003,
+}
+
+// шаг поиска станций, сутки. Самая короткая петля у Меркурия ~ 3 недели
+const stationSearchStep = int64(SEC_IN_1_DAY)
+
+type Station struct {
+	Body          int
+	SecFromJd2000 int64
+	Direction     string // направление после станции, D или R
+}
+
+// определяет направление движения по скорости изменения долготы (радиан в секунду)
+func DirectionByRate(body int, rate float64) string {
+
+	degPerDay := rate * RAD_TO_DEG * float64(SEC_IN_1_DAY)
+
+	if body >= 0 && body < NUMBEROFPLANETSWITHHIRON && math.Abs(degPerDay) < StationaryThreshold[body] {
+		return STATIONARY
+	}
+
+	if degPerDay < 0 {
+		return RETROGRADE
+	}
+
+	return DIRECT
+}
+
+// заполняет FdStructure.Direction для всех планет на момент secFromJd2000
+func (pl *Planets) SetDirections(eph Ephemeris, secFromJd2000 int64) {
+
+	for i := 1; i < NUMBEROFPLANETSWITHHIRON; i++ {
+		if p := pl.Body(i); p != nil {
+			p.Direction = DirectionByRate(i, eph.LongitudeRate(i, secFromJd2000))
+		}
+	}
+}
+
+// ищет точные моменты станций (смена знака скорости по долготе) от from до to
+func FindStations(eph Ephemeris, body int, from, to int64) []Station {
+
+	var stations []Station
+
+	prevTime := from
+	prevRate := eph.LongitudeRate(body, prevTime)
+
+	for prevTime < to {
+
+		nextTime := min(prevTime+stationSearchStep, to)
+		nextRate := eph.LongitudeRate(body, nextTime)
+
+		if (prevRate < 0) != (nextRate < 0) {
+
+			station := bisectRateSign(eph, body, prevTime, nextTime, prevRate)
+
+			dir := DIRECT
+			if nextRate < 0 {
+				dir = RETROGRADE
+			}
+
+			stations = append(stations, Station{Body: body, SecFromJd2000: station, Direction: dir})
+		}
+
+		prevTime, prevRate = nextTime, nextRate
+	}
+
+	return stations
+}
@@ -0,0 +1,62 @@
+package cd_consts_go
+
+import "testing"
+
+func TestFindStations(t *testing.T) {
+
+	eph := loopEphemeris(10)
+	period := int64(100 * SEC_IN_1_DAY)
+
+	stations := FindStations(eph, MERCURY, 0, 2*period)
+
+	want := []Station{
+		{MERCURY, period / 3, RETROGRADE},
+		{MERCURY, 2 * period / 3, DIRECT},
+		{MERCURY, period + period/3, RETROGRADE},
+		{MERCURY, period + 2*period/3, DIRECT},
+	}
+
+	if len(stations) != len(want) {
+		t.Fatalf("got %d stations, want %d: %v", len(stations), len(want), stations)
+	}
+
+	for i, w := range want {
+		s := stations[i]
+		if s.Direction != w.Direction || s.SecFromJd2000 < w.SecFromJd2000-1 || s.SecFromJd2000 > w.SecFromJd2000+1 {
+			t.Errorf("station %d = %+v, want %+v", i, s, w)
+		}
+	}
+}
+
+func TestFindStationsDirectMotion(t *testing.T) {
+
+	eph := fakeEphemeris{start: 10, speed: 1}
+
+	if stations := FindStations(eph, SUN, 0, 400*int64(SEC_IN_1_DAY)); len(stations) != 0 {
+		t.Errorf("got %v, want no stations", stations)
+	}
+}
+
+func TestDirectionByRate(t *testing.T) {
+
+	perDay := RAD_RATIO / float64(SEC_IN_1_DAY)
+
+	tests := []struct {
+		body int
+		deg  float64
+		want string
+	}{
+		{MERCURY, 1, DIRECT},
+		{MERCURY, -1, RETROGRADE},
+		{MERCURY, 0.05, STATIONARY},
+		{MERCURY, -0.05, STATIONARY},
+		{SUN, 0, DIRECT},
+		{NORTHNODE, -0.05, RETROGRADE},
+	}
+
+	for _, tt := range tests {
+		if got := DirectionByRate(tt.body, tt.deg*perDay); got != tt.want {
+			t.Errorf("DirectionByRate(%d, %v°/day) = %s, want %s", tt.body, tt.deg, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package cd_consts_go
+
+import (
+	"errors"
+	"math"
+)
+
+// Ephemeris - источник геоцентрических положений планет.
+// Реализуется пакетом, который читает BspFile, здесь используется только для поиска по времени.
+// body - номер из Planets (MERCURY ... HIRON), время - секунды от JD2000 (Ephemeries time)
+type Ephemeris interface {
+	// эклиптическая долгота в радианах, от 0 до 2*PI
+	Longitude(body int, secFromJd2000 int64) float64
+
+	// скорость изменения долготы в радианах в секунду
+	LongitudeRate(body int, secFromJd2000 int64) float64
+}
+
+// необязательное расширение Ephemeris: подключен ли SPK с Хироном (см. BspFile.HasHiron)
+type HironSupport interface {
+	HasHiron() bool
+}
+
+// необязательное расширение Ephemeris для топоцентрических и гелиоцентрических карт:
+// экваториальные векторы J2000 как в SPK, km и km/s. Узлы Луны векторов не имеют
+type VectorSupport interface {
+	// положение тела относительно центра Земли
+	GeocentricVector(body int, secFromJd2000 int64) Position
+
+	// положение тела относительно SSB, для EARTH - сама Земля (399)
+	BarycentricVector(body int, secFromJd2000 int64) Position
+}
+
+// как считать карту. Для Topocentric нужен Observer, для Topocentric и Heliocentric -
+// эфемериды с VectorSupport
+type ChartOptions struct {
+	Mode     ChartMode
+	Observer *Observer
+}
+
+// положения всех тел геоцентрической карты на момент secFromJd2000: долготы, HdStructure,
+// тропический зодиак, направление движения и TimeData
+func NewHdObjects(eph Ephemeris, secFromJd2000 int64) HdObjects {
+
+	hdo, _ := NewHdObjectsWithOptions(eph, secFromJd2000, ChartOptions{})
+
+	return hdo
+}
+
+// то же, что NewHdObjects, но в режиме opts.Mode
+func NewHdObjectsWithOptions(eph Ephemeris, secFromJd2000 int64, opts ChartOptions) (HdObjects, error) {
+
+	var hdo HdObjects
+	hdo.Planets.Init()
+	hdo.Centers.Init()
+
+	vs, hasVectors := eph.(VectorSupport)
+
+	switch opts.Mode {
+	case Geocentric:
+	case Topocentric:
+		if opts.Observer == nil {
+			return hdo, errors.New("NewHdObjectsWithOptions: topocentric chart needs an observer")
+		}
+		if !hasVectors {
+			return hdo, errors.New("NewHdObjectsWithOptions: topocentric chart needs ephemeris with VectorSupport")
+		}
+	case Heliocentric:
+		if !hasVectors {
+			return hdo, errors.New("NewHdObjectsWithOptions: heliocentric chart needs ephemeris with VectorSupport")
+		}
+	default:
+		return hdo, errors.New("NewHdObjectsWithOptions: unknown chart mode")
+	}
+
+	if hs, ok := eph.(HironSupport); ok && hs.HasHiron() {
+		hdo.EnableHiron()
+	}
+
+	for i := 1; i < NUMBEROFPLANETSWITHHIRON; i++ {
+		if p := hdo.Body(i); p != nil {
+			p.Longitude = eph.Longitude(i, secFromJd2000)
+		}
+	}
+
+	hdo.SetDirections(eph, secFromJd2000)
+
+	switch opts.Mode {
+	case Topocentric:
+		hdo.setTopocentric(vs, opts.Observer, secFromJd2000)
+	case Heliocentric:
+		var ssb [NUMBEROFPLANETSWITHHIRON]Position
+		for i := 1; i < NUMBEROFPLANETSWITHHIRON; i++ {
+			if i != NORTHNODE && i != SOUTHNODE && hdo.Body(i) != nil {
+				ssb[i] = vs.BarycentricVector(i, secFromJd2000)
+			}
+		}
+		hdo.SetHeliocentric(&ssb)
+	}
+
+	hdo.SetHdStructures()
+	hdo.SetZodiac(Tropical, secFromJd2000)
+
+	hdo.TimeData = NewTimeData(secFromJd2000)
+
+	return hdo, nil
+}
+
+// сдвигает геоцентрические долготы на параллакс наблюдателя.
+// Поправка берется как разность топоцентрической и геоцентрической долгот по векторам,
+// так что система долгот Ephemeris не меняется. Земля - напротив топоцентрического Солнца,
+// узлы Луны не меняются
+func (hdo *HdObjects) setTopocentric(vs VectorSupport, obs *Observer, secFromJd2000 int64) {
+
+	for i := 1; i < NUMBEROFPLANETSWITHHIRON; i++ {
+
+		switch i {
+		case EARTH, NORTHNODE, SOUTHNODE:
+			continue
+		}
+
+		p := hdo.Body(i)
+		if p == nil {
+			continue
+		}
+
+		geo := vs.GeocentricVector(i, secFromJd2000)
+		topo := ApparentPosition(geo, obs, secFromJd2000)
+
+		shift := EquatorialToEcliptic(topo, MED_EPS).Polar().Longitude -
+			EquatorialToEcliptic(geo, MED_EPS).Polar().Longitude
+
+		p.Longitude = math.Mod(p.Longitude+shift+4*PI, 2*PI)
+	}
+
+	hdo.Planet[EARTH].Longitude = math.Mod(hdo.Planet[SUN].Longitude+PI, 2*PI)
+
+	hdo.Mode = Topocentric
+}
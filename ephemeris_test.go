@@ -0,0 +1,100 @@
+package cd_consts_go
+
+import "math"
+
+// тестовые эфемериды: равномерное движение со скоростью speed плюс колебание,
+// при amplitude*omega > speed у тела появляются попятные петли.
+// Долгота в градусах lon(t) = start + speed*t + amplitude*sin(omega*t), t в сутках
+type fakeEphemeris struct {
+	start     float64 // градусы
+	speed     float64 // градусы в сутки
+	amplitude float64 // градусы
+	period    float64 // сутки
+}
+
+func (fe fakeEphemeris) days(sec int64) float64 {
+	return float64(sec) / float64(SEC_IN_1_DAY)
+}
+
+func (fe fakeEphemeris) omega() float64 {
+	if fe.period == 0 {
+		return 0
+	}
+	return 2 * PI / fe.period
+}
+
+func (fe fakeEphemeris) degrees(sec int64) float64 {
+	t := fe.days(sec)
+	return fe.start + fe.speed*t + fe.amplitude*math.Sin(fe.omega()*t)
+}
+
+func (fe fakeEphemeris) Longitude(body int, sec int64) float64 {
+
+	lon := math.Mod(fe.degrees(sec), 360)
+	if lon < 0 {
+		lon += 360
+	}
+
+	return lon * RAD_RATIO
+}
+
+func (fe fakeEphemeris) LongitudeRate(body int, sec int64) float64 {
+	t := fe.days(sec)
+	degPerDay := fe.speed + fe.amplitude*fe.omega()*math.Cos(fe.omega()*t)
+	return degPerDay * RAD_RATIO / float64(SEC_IN_1_DAY)
+}
+
+// петля: средняя скорость 1°/сутки, период 100 суток, скорость 1 + 2*cos,
+// станции ровно на 1/3 и 2/3 периода
+func loopEphemeris(start float64) fakeEphemeris {
+	return fakeEphemeris{start: start, speed: 1, amplitude: 2 * 100 / (2 * PI), period: 100}
+}
+
+// тестовые эфемериды с векторами: тела на эклиптике, долготы из fakeEphemeris,
+// расстояния от Земли distance[body]; для гелиоцентрических векторов
+// Солнце в SSB, Земля напротив геоцентрического Солнца
+type vectorEphemeris struct {
+	fakeEphemeris
+	distance map[int]float64 // km
+}
+
+// вектор на эклиптике с долготой lonRad, движущийся по долготе со скоростью rate (радиан в секунду)
+func eclipticVector(lonRad, radius, rate float64) Position {
+
+	sinLon, cosLon := math.Sincos(lonRad)
+	ecl := Position{
+		X:         radius * cosLon,
+		Y:         radius * sinLon,
+		VelocityX: -radius * rate * sinLon,
+		VelocityY: radius * rate * cosLon,
+	}
+
+	return EquatorialToEcliptic(ecl, -MED_EPS)
+}
+
+func (ve vectorEphemeris) GeocentricVector(body int, sec int64) Position {
+	return eclipticVector(ve.Longitude(body, sec), ve.distance[body], ve.LongitudeRate(body, sec))
+}
+
+func (ve vectorEphemeris) BarycentricVector(body int, sec int64) Position {
+
+	sunToEarth := eclipticVector(ve.Longitude(SUN, sec)+PI, AU, ve.LongitudeRate(SUN, sec))
+
+	switch body {
+	case SUN:
+		return Position{}
+	case EARTH:
+		return sunToEarth
+	}
+
+	geo := ve.GeocentricVector(body, sec)
+
+	return Position{
+		X:         sunToEarth.X + geo.X,
+		Y:         sunToEarth.Y + geo.Y,
+		Z:         sunToEarth.Z + geo.Z,
+		VelocityX: sunToEarth.VelocityX + geo.VelocityX,
+		VelocityY: sunToEarth.VelocityY + geo.VelocityY,
+		VelocityZ: sunToEarth.VelocityZ + geo.VelocityZ,
+	}
+}
@@ -0,0 +1,179 @@
+package cd_consts_go
+
+import (
+	"errors"
+	"sort"
+)
+
+// групповая форма: набор каналов, которые группа должна закрыть
+type GroupForm struct {
+	Name     string
+	Channels []int // номера в ChannelTable
+}
+
+// пента: 6 каналов между G, горлом и сакралом (7-31, 1-8, 13-33, 5-15, 14-2, 29-46)
+var Penta = GroupForm{Name: "Penta", Channels: []int{14, 15, 16, 20, 21, 22}}
+
+// WA: племенные каналы (контуры эго и защиты) по ChannelTable -
+// 21-45, 44-26, 27-50, 59-6, 37-40, 54-32, 19-49
+var WA = GroupForm{Name: "WA", Channels: circuitGroupChannels(TribalCircuitry)}
+
+func circuitGroupChannels(group CircuitGroup) []int {
+
+	var channels []int
+	for ch := 1; ch < NUMBEROFCHANNELS; ch++ {
+		if ChannelTable[ch].Circuit.Group() == group {
+			channels = append(channels, ch)
+		}
+	}
+
+	return channels
+}
+
+// ворота формы по порядку каналов
+func (gf GroupForm) Gates() []int {
+
+	gates := make([]int, 0, 2*len(gf.Channels))
+	for _, ch := range gf.Channels {
+		gates = append(gates, ChannelTable[ch].FirstGate, ChannelTable[ch].SecondGate)
+	}
+
+	return gates
+}
+
+// кто из группы держит ворота, индексы участников в порядке передачи в AnalyzeGroup
+type GateHolders struct {
+	Gate    int
+	Members []int
+}
+
+// вклад в канал: кто держит канал целиком и кто хотя бы одни его ворота
+type ChannelContribution struct {
+	Channel      int
+	Defined      bool  // канал определен в группе
+	Full         []int // у кого канал целиком
+	Contributors []int // у кого есть хотя бы одни ворота канала
+}
+
+type FormCoverage struct {
+	Form     string
+	Gates    []GateHolders // все ворота формы, Members пустой - ворота не заполнены
+	Gaps     []int         // ворота формы, которых нет ни у кого
+	Channels []ChannelContribution
+	Filled   int // сколько ворот формы заполнено
+}
+
+type GroupAnalysis struct {
+	Members int
+
+	// общая карта группы: объединение ворот, центры, тип и определение
+	Combined HdInfo
+
+	// кто держит каждые ворота, from 1 to 64
+	Holders [NUMBEROFGATES][]int
+
+	Forms []FormCoverage
+
+	// каналы, определенные в группе, от самых сильных: больше всего у кого целиком,
+	// затем больше всего участников с воротами канала
+	Strongest []ChannelContribution
+}
+
+// анализ группы из 3 - 5 человек. forms - формы для покрытия, по умолчанию Penta и WA.
+// Ворота участников должны быть уже активированы
+func AnalyzeGroup(members []*HdInfo, forms ...GroupForm) (GroupAnalysis, error) {
+
+	var ga GroupAnalysis
+
+	if len(members) < 3 || len(members) > 5 {
+		return ga, errors.New("AnalyzeGroup: a group must have 3 to 5 members")
+	}
+
+	if len(forms) == 0 {
+		forms = []GroupForm{Penta, WA}
+	}
+
+	ga.Members = len(members)
+	ga.Combined.Init()
+
+	for m, hd := range members {
+		for i := 1; i < NUMBEROFGATES; i++ {
+
+			if !hd.Gates[i].Defined {
+				continue
+			}
+
+			ga.Holders[i] = append(ga.Holders[i], m)
+			ga.Combined.Gates[i].Pers += hd.Gates[i].Pers
+			ga.Combined.Gates[i].Des += hd.Gates[i].Des
+			ga.Combined.Gates[i].Defined = true
+		}
+	}
+
+	ga.Combined.DefineCenters()
+	ga.Combined.DeriveType()
+
+	for _, form := range forms {
+		ga.Forms = append(ga.Forms, ga.coverage(members, form))
+	}
+
+	for ch := 1; ch < NUMBEROFCHANNELS; ch++ {
+		if ga.Combined.Channels[ch].Defined {
+			ga.Strongest = append(ga.Strongest, contribution(members, ch, true))
+		}
+	}
+
+	sort.SliceStable(ga.Strongest, func(i, j int) bool {
+		a, b := ga.Strongest[i], ga.Strongest[j]
+		if len(a.Full) != len(b.Full) {
+			return len(a.Full) > len(b.Full)
+		}
+		return len(a.Contributors) > len(b.Contributors)
+	})
+
+	return ga, nil
+}
+
+func (ga *GroupAnalysis) coverage(members []*HdInfo, form GroupForm) FormCoverage {
+
+	fc := FormCoverage{Form: form.Name}
+
+	for _, gate := range form.Gates() {
+
+		// копия, чтобы изменения в отчете по форме не меняли Holders
+		members := append([]int(nil), ga.Holders[gate]...)
+		fc.Gates = append(fc.Gates, GateHolders{Gate: gate, Members: members})
+
+		if len(ga.Holders[gate]) == 0 {
+			fc.Gaps = append(fc.Gaps, gate)
+		} else {
+			fc.Filled++
+		}
+	}
+
+	for _, ch := range form.Channels {
+		fc.Channels = append(fc.Channels, contribution(members, ch, ga.Combined.Channels[ch].Defined))
+	}
+
+	return fc
+}
+
+func contribution(members []*HdInfo, ch int, defined bool) ChannelContribution {
+
+	info := ChannelTable[ch]
+	cc := ChannelContribution{Channel: ch, Defined: defined}
+
+	for m, hd := range members {
+
+		first, second := hd.Gates[info.FirstGate].Defined, hd.Gates[info.SecondGate].Defined
+
+		if first && second {
+			cc.Full = append(cc.Full, m)
+		}
+		if first || second {
+			cc.Contributors = append(cc.Contributors, m)
+		}
+	}
+
+	return cc
+}
@@ -0,0 +1,74 @@
+package cd_consts_go
+
+import (
+	"reflect"
+	"testing"
+)
+
+// карта, у которой активированы только ворота gates
+func chartWithGates(gates ...int) *HdInfo {
+
+	var hd HdInfo
+	hd.Init()
+	for _, g := range gates {
+		hd.Gates[g].Pers = 1
+		hd.Gates[g].Defined = true
+	}
+	hd.DefineCenters()
+
+	return &hd
+}
+
+func TestWAForm(t *testing.T) {
+
+	if got, want := WA.Channels, []int{17, 24, 25, 26, 27, 28, 34}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WA channels %v, want %v", got, want)
+	}
+}
+
+func TestAnalyzeGroup(t *testing.T) {
+
+	// 7-31 у первого целиком, 1-8 делят второй и третий, 45 у третьего без 21
+	members := []*HdInfo{
+		chartWithGates(7, 31),
+		chartWithGates(1),
+		chartWithGates(8, 45),
+	}
+
+	ga, err := AnalyzeGroup(members)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ga.Forms) != 2 || ga.Forms[0].Form != "Penta" || ga.Forms[1].Form != "WA" {
+		t.Fatalf("forms %+v, want Penta and WA", ga.Forms)
+	}
+
+	penta := ga.Forms[0]
+	if penta.Filled != 4 || len(penta.Gaps) != 8 {
+		t.Errorf("penta filled %d, gaps %v; want 4 filled and 8 gaps", penta.Filled, penta.Gaps)
+	}
+
+	wa := ga.Forms[1]
+	if wa.Filled != 1 {
+		t.Errorf("WA filled %d, want 1 (gate 45)", wa.Filled)
+	}
+
+	if len(ga.Strongest) != 2 || ga.Strongest[0].Channel != 14 || !reflect.DeepEqual(ga.Strongest[0].Full, []int{0}) {
+		t.Errorf("strongest %+v, want 7-31 first", ga.Strongest)
+	}
+
+	// отчет по форме не делит память с Holders
+	for _, gh := range penta.Gates {
+		if gh.Gate == 1 {
+			gh.Members[0] = 99
+		}
+	}
+	if ga.Holders[1][0] != 1 {
+		t.Errorf("Holders[1] changed through FormCoverage: %v", ga.Holders[1])
+	}
+
+	if _, err := AnalyzeGroup(members[:2]); err == nil {
+		t.Error("want an error for a group of 2")
+	}
+}
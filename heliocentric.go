@@ -0,0 +1,51 @@
+package cd_consts_go
+
+// гелиоцентрическая карта.
+// ssb - экваториальные положения тел относительно SSB (как в SPK), индексы как в Planets,
+// для EARTH - сама Земля (399), а не барицентр Земля-Луна.
+// В слот Солнца ставится Земля, в слот Земли - противоположная ей точка,
+// чтобы пары Солнце/Земля для креста и профиля остались на своих местах.
+// Узлы Луны гелиоцентрического аналога не имеют и остаются как были.
+// Direction пересчитывается по гелиоцентрической скорости, HdStructure и зодиак - нет
+func (hdo *HdObjects) SetHeliocentric(ssb *[NUMBEROFPLANETSWITHHIRON]Position) {
+
+	sun := ssb[SUN]
+
+	for i := 1; i < NUMBEROFPLANETSWITHHIRON; i++ {
+
+		switch i {
+		case SUN, EARTH, NORTHNODE, SOUTHNODE:
+			continue
+		}
+
+		if p := hdo.Body(i); p != nil {
+			p.Longitude, p.Direction = heliocentricLongitude(i, ssb[i], sun)
+		}
+	}
+
+	earth, dir := heliocentricLongitude(EARTH, ssb[EARTH], sun)
+
+	hdo.Planet[SUN].Longitude = earth
+	hdo.Planet[SUN].Direction = dir
+
+	hdo.Planet[EARTH].Longitude = earth + PI
+	if hdo.Planet[EARTH].Longitude >= 2*PI {
+		hdo.Planet[EARTH].Longitude -= 2 * PI
+	}
+	hdo.Planet[EARTH].Direction = dir
+
+	hdo.Mode = Heliocentric
+}
+
+// долгота относительно Солнца и направление движения по скорости из векторов
+func heliocentricLongitude(body int, pos, sun Position) (float64, string) {
+
+	ecl := EquatorialToEcliptic(pos.Sub(sun), MED_EPS)
+
+	var rate float64
+	if r2 := ecl.X*ecl.X + ecl.Y*ecl.Y; r2 > 0 {
+		rate = (ecl.X*ecl.VelocityY - ecl.Y*ecl.VelocityX) / r2
+	}
+
+	return ecl.Polar().Longitude, DirectionByRate(body, rate)
+}
@@ -0,0 +1,58 @@
+package cd_consts_go
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewHdObjectsHeliocentric(t *testing.T) {
+
+	eph := vectorEphemeris{
+		fakeEphemeris: fakeEphemeris{start: 100, speed: 1},
+		distance:      map[int]float64{MOON: 384400, SUN: AU},
+	}
+	for body := MERCURY; body <= PLUTO; body++ {
+		eph.distance[body] = 5 * AU
+	}
+
+	sec := int64(820497600)
+
+	geo := NewHdObjects(eph, sec)
+	helio, err := NewHdObjectsWithOptions(eph, sec, ChartOptions{Mode: Heliocentric})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if helio.Mode != Heliocentric {
+		t.Errorf("mode %v, want Heliocentric", helio.Mode)
+	}
+
+	near := func(a, b float64) bool { return math.Abs(angleDiff(a, b)) < 1e-9 }
+
+	// в слоте Солнца - Земля, она напротив геоцентрического Солнца
+	if !near(helio.Planet[SUN].Longitude, geo.Planet[SUN].Longitude+PI) {
+		t.Errorf("Sun slot %.6f, want Earth opposite the geocentric Sun", helio.Planet[SUN].Longitude)
+	}
+	if !near(helio.Planet[EARTH].Longitude, geo.Planet[SUN].Longitude) {
+		t.Errorf("Earth slot %.6f, want the geocentric Sun", helio.Planet[EARTH].Longitude)
+	}
+	if helio.Planet[SUN].Direction != DIRECT || helio.Planet[EARTH].Direction != DIRECT {
+		t.Errorf("Sun/Earth slots move %s/%s, want D/D", helio.Planet[SUN].Direction, helio.Planet[EARTH].Direction)
+	}
+
+	// все тела на одной геоцентрической долготе, из-за разных расстояний гелиоцентрические разные
+	if near(helio.Planet[MOON].Longitude, helio.Planet[MARS].Longitude) {
+		t.Error("Moon and Mars must differ in a heliocentric chart")
+	}
+
+	if helio.Planet[NORTHNODE].Longitude != geo.Planet[NORTHNODE].Longitude {
+		t.Error("lunar nodes must not change")
+	}
+	if helio.Planet[SUN].Hex != NewHdStructure(helio.Planet[SUN].Longitude).Hex {
+		t.Error("HdStructure was not recalculated")
+	}
+
+	if _, err := NewHdObjectsWithOptions(fakeEphemeris{speed: 1}, sec, ChartOptions{Mode: Heliocentric}); err == nil {
+		t.Error("want an error for ephemeris without VectorSupport")
+	}
+}
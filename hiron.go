@@ -0,0 +1,53 @@
+package cd_consts_go
+
+import (
+	"bytes"
+	"errors"
+)
+
+// дополнительный SPK с малыми телами (например chiron_1800_2200.bsp из JPL Horizons)
+// de440s не содержит Хирона, поэтому он подключается отдельным файлом
+type SmallBodySpk struct {
+	FilePtr  *bytes.Reader
+	FileInfo *FileInfo
+}
+
+// подключает к BspFile дополнительный SPK,
+// fileInfo должен быть уже заполнен (summaries прочитаны из файла)
+func (bsp *BspFile) AttachSmallBodySpk(filePtr *bytes.Reader, fileInfo *FileInfo) error {
+
+	if filePtr == nil || fileInfo == nil {
+		return errors.New("AttachSmallBodySpk: empty file or file info")
+	}
+
+	if _, ok := fileInfo.Summary(HIRON_NAIF_CODE); !ok {
+		return errors.New("AttachSmallBodySpk: no CHIRON (2002060) segment in " + fileInfo.FileName)
+	}
+
+	bsp.SmallBodies = &SmallBodySpk{FilePtr: filePtr, FileInfo: fileInfo}
+
+	return nil
+}
+
+// можно ли считать Хирон, т.е. подключен ли SPK с ним
+func (bsp *BspFile) HasHiron() bool {
+
+	if bsp.SmallBodies == nil || bsp.SmallBodies.FileInfo == nil {
+		return false
+	}
+
+	_, ok := bsp.SmallBodies.FileInfo.Summary(HIRON_NAIF_CODE)
+	return ok
+}
+
+// ищет сегмент по NAIF коду объекта
+func (fi *FileInfo) Summary(targetCode int) (SummariesLines, bool) {
+
+	for _, sl := range fi.SummariesLineStruct {
+		if sl.TargetCode == targetCode {
+			return sl, true
+		}
+	}
+
+	return SummariesLines{}, false
+}
@@ -0,0 +1,85 @@
+package cd_consts_go
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+// эфемериды с подключенным Хироном
+type hironEphemeris struct {
+	fakeEphemeris
+}
+
+func (hironEphemeris) HasHiron() bool { return true }
+
+func TestChartWithoutHiron(t *testing.T) {
+
+	hdo := NewHdObjects(fakeEphemeris{start: 100, speed: 1}, 0)
+
+	if hdo.Hiron != nil || hdo.Body(HIRON) != nil {
+		t.Fatal("Hiron must be nil without HironSupport")
+	}
+
+	b, err := json.Marshal(hdo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "Hiron") {
+		t.Error("JSON of a chart without Hiron must not mention it")
+	}
+}
+
+func TestChartWithHiron(t *testing.T) {
+
+	eph := hironEphemeris{fakeEphemeris{start: 100, speed: -1}}
+	hdo := NewHdObjects(eph, 0)
+
+	h := hdo.Body(HIRON)
+	if h == nil || h != hdo.Hiron {
+		t.Fatal("Hiron must be calculated when HasHiron() is true")
+	}
+
+	if math.Abs(h.Longitude-100*RAD_RATIO) > 1e-12 {
+		t.Errorf("Longitude %v, want 100°", h.Longitude*RAD_TO_DEG)
+	}
+	if h.HdStructure != NewHdStructure(h.Longitude) {
+		t.Errorf("HdStructure %+v", h.HdStructure)
+	}
+	if h.ZodiacStructure != NewZodiacStructure(h.Longitude, Tropical, 0) {
+		t.Errorf("ZodiacStructure %+v", h.ZodiacStructure)
+	}
+	if h.Direction != RETROGRADE {
+		t.Errorf("Direction %q, want R", h.Direction)
+	}
+
+	b, err := json.Marshal(hdo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"Hiron":{`) {
+		t.Error("JSON of a chart with Hiron must contain it")
+	}
+}
+
+func TestAttachSmallBodySpk(t *testing.T) {
+
+	var bsp BspFile
+	file := bytes.NewReader(nil)
+
+	noHiron := &FileInfo{FileName: "asteroids.bsp", SummariesLineStruct: []SummariesLines{{TargetCode: 2000001}}}
+	if err := bsp.AttachSmallBodySpk(file, noHiron); err == nil || bsp.HasHiron() {
+		t.Error("a file without the 2002060 segment must be rejected")
+	}
+
+	if err := bsp.AttachSmallBodySpk(nil, nil); err == nil {
+		t.Error("an empty file must be rejected")
+	}
+
+	withHiron := &FileInfo{FileName: "chiron.bsp", SummariesLineStruct: []SummariesLines{{TargetCode: HIRON_NAIF_CODE}}}
+	if err := bsp.AttachSmallBodySpk(file, withHiron); err != nil || !bsp.HasHiron() {
+		t.Errorf("attach failed: %v", err)
+	}
+}
@@ -0,0 +1,284 @@
+package cd_consts_go
+
+import (
+	"container/heap"
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// то, что индекс хранит о карте: основные характеристики и множества ворот, каналов, центров
+type ChartRecord struct {
+	ID         string
+	Type       EnergyType
+	Authority  Authority
+	Profile    string
+	Definition Definition
+	Gates      GateSet
+	Channels   ChannelSet
+	Centers    CenterSet
+}
+
+func NewChartRecord(id string, hd *HdInfo) ChartRecord {
+	return ChartRecord{
+		ID:         id,
+		Type:       hd.Type,
+		Authority:  hd.Authority,
+		Profile:    hd.Profile,
+		Definition: hd.Definition,
+		Gates:      hd.GateSet(),
+		Channels:   hd.ChannelSet(),
+		Centers:    hd.CenterSet(),
+	}
+}
+
+// фильтр по характеристикам, нулевые значения - любые
+type ChartQuery struct {
+	Type       EnergyType
+	Authority  Authority
+	Profile    string
+	Definition Definition
+}
+
+func (q ChartQuery) match(r *ChartRecord) bool {
+	return (q.Type == 0 || q.Type == r.Type) &&
+		(q.Authority == 0 || q.Authority == r.Authority) &&
+		(q.Profile == "" || q.Profile == r.Profile) &&
+		(q.Definition == 0 || q.Definition == r.Definition)
+}
+
+// результат поиска похожих карт
+type ChartMatch struct {
+	ID    string
+	Score float64
+}
+
+// индекс карт в памяти, безопасен для одновременного использования
+type ChartIndex struct {
+	mu      sync.RWMutex
+	records []ChartRecord
+	byID    map[string]int
+}
+
+func NewChartIndex() *ChartIndex {
+	return &ChartIndex{byID: make(map[string]int)}
+}
+
+// добавляет карту или заменяет карту с тем же id
+func (ix *ChartIndex) Add(id string, hd *HdInfo) {
+	ix.AddRecord(NewChartRecord(id, hd))
+}
+
+func (ix *ChartIndex) AddRecord(r ChartRecord) {
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	if i, ok := ix.byID[r.ID]; ok {
+		ix.records[i] = r
+		return
+	}
+
+	ix.byID[r.ID] = len(ix.records)
+	ix.records = append(ix.records, r)
+}
+
+func (ix *ChartIndex) Remove(id string) bool {
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	i, ok := ix.byID[id]
+	if !ok {
+		return false
+	}
+
+	// на место удаленной ставим последнюю запись
+	last := len(ix.records) - 1
+	ix.records[i] = ix.records[last]
+	ix.byID[ix.records[i].ID] = i
+	ix.records = ix.records[:last]
+	delete(ix.byID, id)
+
+	return true
+}
+
+func (ix *ChartIndex) Get(id string) (ChartRecord, bool) {
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	i, ok := ix.byID[id]
+	if !ok {
+		return ChartRecord{}, false
+	}
+
+	return ix.records[i], true
+}
+
+func (ix *ChartIndex) Len() int {
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	return len(ix.records)
+}
+
+// id карт, подходящих под фильтр, по возрастанию
+func (ix *ChartIndex) Find(q ChartQuery) []string {
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	var ids []string
+	for i := range ix.records {
+		if q.match(&ix.records[i]) {
+			ids = append(ids, ix.records[i].ID)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// k карт с наибольшим числом общих с channels каналов, Score - число общих каналов
+func (ix *ChartIndex) SharedChannels(channels ChannelSet, q ChartQuery, k int) []ChartMatch {
+	return ix.topK(q, k, func(r *ChartRecord) float64 {
+		return float64(r.Channels.Intersect(channels).Count())
+	})
+}
+
+// k карт, самых похожих по воротам, Score - коэффициент Жаккара от 0 до 1
+func (ix *ChartIndex) Similar(gates GateSet, q ChartQuery, k int) []ChartMatch {
+	return ix.topK(q, k, func(r *ChartRecord) float64 {
+		union := r.Gates.Union(gates).Count()
+		if union == 0 {
+			return 0
+		}
+		return float64(r.Gates.Intersect(gates).Count()) / float64(union)
+	})
+}
+
+// лучшие k по score, нулевой score не возвращается; при равенстве - по id
+func (ix *ChartIndex) topK(q ChartQuery, k int, score func(*ChartRecord) float64) []ChartMatch {
+
+	if k <= 0 {
+		return nil
+	}
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	h := make(matchHeap, 0, k)
+	for i := range ix.records {
+
+		r := &ix.records[i]
+		if !q.match(r) {
+			continue
+		}
+
+		m := ChartMatch{ID: r.ID, Score: score(r)}
+		if m.Score <= 0 {
+			continue
+		}
+
+		if len(h) < k {
+			heap.Push(&h, m)
+		} else if better(m, h[0]) {
+			h[0] = m
+			heap.Fix(&h, 0)
+		}
+	}
+
+	sort.Slice(h, func(i, j int) bool { return better(h[i], h[j]) })
+
+	return h
+}
+
+func better(a, b ChartMatch) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	return a.ID < b.ID
+}
+
+// min-heap: в вершине худший из лучших k
+type matchHeap []ChartMatch
+
+func (h matchHeap) Len() int           { return len(h) }
+func (h matchHeap) Less(i, j int) bool { return better(h[j], h[i]) }
+func (h matchHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *matchHeap) Push(x any)        { *h = append(*h, x.(ChartMatch)) }
+func (h *matchHeap) Pop() any {
+	old := *h
+	m := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return m
+}
+
+// версия формата файла индекса
+const chartIndexVersion = 1
+
+type chartIndexFile struct {
+	Version int
+	Records []ChartRecord
+}
+
+// записывает индекс в w (gob)
+func (ix *ChartIndex) Save(w io.Writer) error {
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	return gob.NewEncoder(w).Encode(chartIndexFile{Version: chartIndexVersion, Records: ix.records})
+}
+
+// читает индекс, записанный Save
+func LoadChartIndex(r io.Reader) (*ChartIndex, error) {
+
+	var f chartIndexFile
+	if err := gob.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+
+	if f.Version != chartIndexVersion {
+		return nil, errors.New("LoadChartIndex: unsupported index version")
+	}
+
+	ix := NewChartIndex()
+	for _, rec := range f.Records {
+		ix.AddRecord(rec)
+	}
+
+	return ix, nil
+}
+
+func (ix *ChartIndex) SaveFile(path string) error {
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := ix.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func LoadChartIndexFile(path string) (*ChartIndex, error) {
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadChartIndex(f)
+}
@@ -0,0 +1,139 @@
+package cd_consts_go
+
+import (
+	"bytes"
+	"encoding/gob"
+	"reflect"
+	"testing"
+)
+
+// индекс из четырех карт, добавленных не по порядку id:
+// a и c - каналы 34-20 и 7-31, b - только 34-20, d - 64-47
+func testChartIndex() *ChartIndex {
+
+	ix := NewChartIndex()
+	for _, c := range []struct {
+		id, profile string
+		gates       []int
+	}{
+		{"c", "2/4", []int{34, 20, 7, 31}},
+		{"b", "1/3", []int{34, 20}},
+		{"a", "1/3", []int{34, 20, 7, 31}},
+		{"d", "1/3", []int{64, 47}},
+	} {
+		hd := chartWithGates(c.gates...)
+		hd.DeriveType()
+		hd.Profile = c.profile
+		ix.Add(c.id, hd)
+	}
+
+	return ix
+}
+
+func TestChartIndexSaveLoad(t *testing.T) {
+
+	for _, ix := range []*ChartIndex{testChartIndex(), NewChartIndex()} {
+
+		var buf bytes.Buffer
+		if err := ix.Save(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		loaded, err := LoadChartIndex(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if loaded.Len() != ix.Len() {
+			t.Fatalf("loaded %d charts, saved %d", loaded.Len(), ix.Len())
+		}
+		for _, id := range ix.Find(ChartQuery{}) {
+			want, _ := ix.Get(id)
+			got, ok := loaded.Get(id)
+			if !ok || !reflect.DeepEqual(got, want) {
+				t.Errorf("chart %s: loaded %+v, saved %+v", id, got, want)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(chartIndexFile{Version: chartIndexVersion + 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadChartIndex(&buf); err == nil {
+		t.Error("want an error for an unknown index version")
+	}
+}
+
+func TestChartIndexRemove(t *testing.T) {
+
+	ix := testChartIndex()
+
+	if !ix.Remove("b") {
+		t.Fatal("Remove(b) = false")
+	}
+	if ix.Remove("b") {
+		t.Error("second Remove(b) = true")
+	}
+	if _, ok := ix.Get("b"); ok {
+		t.Error("removed chart is still in the index")
+	}
+
+	// на место b встала последняя запись d, она должна находиться по id
+	for _, id := range []string{"a", "c", "d"} {
+		if r, ok := ix.Get(id); !ok || r.ID != id {
+			t.Errorf("Get(%s) = %q, %v", id, r.ID, ok)
+		}
+	}
+	if ix.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", ix.Len())
+	}
+}
+
+func TestChartIndexFind(t *testing.T) {
+
+	ix := testChartIndex()
+
+	if got, want := ix.Find(ChartQuery{Profile: "1/3"}), []string{"a", "b", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Find(1/3) = %v, want %v", got, want)
+	}
+	if got, want := ix.Find(ChartQuery{Type: ManifestingGenerator, Definition: SingleDefinition}), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Find(MG, single) = %v, want %v", got, want)
+	}
+	if got := ix.Find(ChartQuery{Definition: SplitDefinition}); got != nil {
+		t.Errorf("Find(split) = %v, want none", got)
+	}
+}
+
+func TestChartIndexRanking(t *testing.T) {
+
+	ix := testChartIndex()
+	ref := chartWithGates(34, 20, 7, 31)
+
+	tests := []struct {
+		name string
+		got  []ChartMatch
+		want []ChartMatch
+	}{
+		{"shared channels", ix.SharedChannels(ref.ChannelSet(), ChartQuery{}, 10),
+			[]ChartMatch{{"a", 2}, {"c", 2}, {"b", 1}}},
+		{"shared channels, k = 2", ix.SharedChannels(ref.ChannelSet(), ChartQuery{}, 2),
+			[]ChartMatch{{"a", 2}, {"c", 2}}},
+		{"shared channels, 1/3", ix.SharedChannels(ref.ChannelSet(), ChartQuery{Profile: "1/3"}, 10),
+			[]ChartMatch{{"a", 2}, {"b", 1}}},
+		{"similar", ix.Similar(ref.GateSet(), ChartQuery{}, 10),
+			[]ChartMatch{{"a", 1}, {"c", 1}, {"b", 0.5}}},
+		{"similar, k = 1", ix.Similar(ref.GateSet(), ChartQuery{}, 1),
+			[]ChartMatch{{"a", 1}}},
+	}
+
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s: %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if got := ix.Similar(ref.GateSet(), ChartQuery{}, 0); got != nil {
+		t.Errorf("k = 0: %v, want none", got)
+	}
+}
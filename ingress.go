@@ -0,0 +1,177 @@
+package cd_consts_go
+
+// уровень деления мандалы для поиска входов
+type Granularity int
+
+const (
+	ByGate Granularity = iota
+	ByLine
+	ByColor
+	ByTone
+	ByBase
+)
+
+func (g Granularity) String() string {
+	switch g {
+	case ByGate:
+		return "Gate"
+	case ByLine:
+		return "Line"
+	case ByColor:
+		return "Color"
+	case ByTone:
+		return "Tone"
+	case ByBase:
+		return "Base"
+	}
+	return "Unknown Granularity"
+}
+
+// сколько баз в одном делении
+func (g Granularity) bases() int {
+	switch g {
+	case ByLine:
+		return 180
+	case ByColor:
+		return 30
+	case ByTone:
+		return 5
+	case ByBase:
+		return 1
+	}
+	return basesInHex
+}
+
+// максимальная геоцентрическая скорость тел в градусах в сутки, с запасом
+var maxSpeedDegPerDay = [NUMBEROFPLANETSWITHHIRON]float64{
+	SSB:       0,
+	MERCURY:   2.3,
+	VENUS:     1.3,
+	EARTH:     1.1,
+	MARS:      0.8,
+	JUPITER:   0.25,
+	SATURN:    0.14,
+	URANUS:    0.07,
+	NEPTUNE:   0.04,
+	PLUTO:     0.05,
+	SUN:       1.1,
+	MOON:      15.5,
+	NORTHNODE: 0.3,
+	SOUTHNODE: 0.3,
+	HIRON:     0.16,
+}
+
+// вход тела в новые ворота, линию, цвет, тон или базу
+type Ingress struct {
+	Body          int
+	SecFromJd2000 int64 // первая секунда в новом делении
+	From          HdStructure
+	To            HdStructure
+	Direction     string // DIRECT или RETROGRADE - в какую сторону пересечена граница
+}
+
+// номер деления от начала колеса
+func unitOf(longitudeRad float64, g Granularity) int {
+	return wheelBase(longitudeRad*RAD_TO_DEG) / g.bases()
+}
+
+// шаг сканирования: за шаг тело проходит не больше половины деления,
+// и не больше суток, чтобы не пропустить станции
+func ingressStep(body int, g Granularity) int64 {
+
+	step := stationSearchStep
+
+	if body > 0 && body < NUMBEROFPLANETSWITHHIRON && maxSpeedDegPerDay[body] > 0 {
+		unitDeg := float64(g.bases()) * OneBaseInDec
+		byUnit := int64(unitDeg / 2 / maxSpeedDegPerDay[body] * float64(SEC_IN_1_DAY))
+		step = min(step, max(byUnit, 1))
+	}
+
+	return step
+}
+
+// все входы тела в новые деления уровня g от from до to.
+// Ретроградные возвраты тоже находятся: если внутри шага скорость меняет знак,
+// шаг делится в момент станции и каждая половина проверяется отдельно
+func FindIngresses(eph Ephemeris, body int, from, to int64, g Granularity) []Ingress {
+
+	var ingresses []Ingress
+
+	step := ingressStep(body, g)
+
+	t0 := from
+	rate0 := eph.LongitudeRate(body, t0)
+
+	for t0 < to {
+
+		t1 := min(t0+step, to)
+		rate1 := eph.LongitudeRate(body, t1)
+
+		if (rate0 < 0) != (rate1 < 0) {
+			station := bisectRateSign(eph, body, t0, t1, rate0)
+			ingresses = appendIngress(ingresses, eph, body, t0, station, g)
+			ingresses = appendIngress(ingresses, eph, body, station, t1, g)
+		} else {
+			ingresses = appendIngress(ingresses, eph, body, t0, t1, g)
+		}
+
+		t0, rate0 = t1, rate1
+	}
+
+	return ingresses
+}
+
+// момент смены знака скорости между left и right с точностью до секунды
+func bisectRateSign(eph Ephemeris, body int, left, right int64, leftRate float64) int64 {
+
+	for right-left > 1 {
+		mid := left + (right-left)/2
+		midRate := eph.LongitudeRate(body, mid)
+		if (leftRate < 0) == (midRate < 0) {
+			left, leftRate = mid, midRate
+		} else {
+			right = mid
+		}
+	}
+
+	return right
+}
+
+// если на [t0, t1] деление сменилось, находим бисекцией первую секунду в новом делении.
+// На отрезке без станций тело движется в одну сторону, так что граница одна
+func appendIngress(ingresses []Ingress, eph Ephemeris, body int, t0, t1 int64, g Granularity) []Ingress {
+
+	lon0 := eph.Longitude(body, t0)
+	unit0 := unitOf(lon0, g)
+
+	if unitOf(eph.Longitude(body, t1), g) == unit0 {
+		return ingresses
+	}
+
+	left, right := t0, t1
+	for right-left > 1 {
+		mid := left + (right-left)/2
+		if unitOf(eph.Longitude(body, mid), g) == unit0 {
+			left = mid
+		} else {
+			right = mid
+		}
+	}
+
+	lonLeft := eph.Longitude(body, left)
+	lonRight := eph.Longitude(body, right)
+
+	dir := DIRECT
+	// с учетом перехода через 0
+	if d := lonRight - lonLeft; (d < 0 && d > -PI) || d > PI {
+		dir = RETROGRADE
+	}
+
+	return append(ingresses, Ingress{
+		Body:          body,
+		SecFromJd2000: right,
+		From:          NewHdStructure(lonLeft),
+		To:            NewHdStructure(lonRight),
+		Direction:     dir,
+	})
+}
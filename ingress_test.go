@@ -0,0 +1,126 @@
+package cd_consts_go
+
+import "testing"
+
+// все смены деления с шагом sample секунд: время первой точки в новом делении
+func bruteIngresses(eph Ephemeris, body int, from, to, sample int64, g Granularity) []int64 {
+
+	var times []int64
+
+	prev := unitOf(eph.Longitude(body, from), g)
+	for t := from + sample; t <= to; t += sample {
+		if u := unitOf(eph.Longitude(body, t), g); u != prev {
+			times = append(times, t)
+			prev = u
+		}
+	}
+
+	return times
+}
+
+func checkIngresses(t *testing.T, eph Ephemeris, body int, from, to int64, g Granularity) []Ingress {
+
+	t.Helper()
+
+	const sample = 60
+
+	got := FindIngresses(eph, body, from, to, g)
+	want := bruteIngresses(eph, body, from, to, sample, g)
+
+	if len(want) == 0 {
+		t.Fatalf("%v: the test range has no boundaries", g)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("%v: got %d ingresses, brute force finds %d", g, len(got), len(want))
+	}
+
+	for i, ing := range got {
+
+		if ing.SecFromJd2000 <= want[i]-sample || ing.SecFromJd2000 > want[i] {
+			t.Errorf("%v ingress %d at %d, brute force puts it in (%d, %d]", g, i, ing.SecFromJd2000, want[i]-sample, want[i])
+		}
+
+		// первая секунда в новом делении
+		before := unitOf(eph.Longitude(body, ing.SecFromJd2000-1), g)
+		after := unitOf(eph.Longitude(body, ing.SecFromJd2000), g)
+		if before == after {
+			t.Errorf("%v ingress %d at %d is not a boundary", g, i, ing.SecFromJd2000)
+		}
+
+		wantDir := DIRECT
+		if eph.LongitudeRate(body, ing.SecFromJd2000) < 0 {
+			wantDir = RETROGRADE
+		}
+		if ing.Direction != wantDir {
+			t.Errorf("%v ingress %d direction %s, want %s", g, i, ing.Direction, wantDir)
+		}
+	}
+
+	return got
+}
+
+func TestFindIngressesRetrogradeLoop(t *testing.T) {
+
+	eph := loopEphemeris(10)
+	period := int64(100 * SEC_IN_1_DAY)
+
+	for _, g := range []Granularity{ByGate, ByLine} {
+
+		ingresses := checkIngresses(t, eph, MERCURY, 0, 2*period, g)
+
+		// в попятной петле тело возвращается в уже пройденные ворота
+		retrograde := 0
+		entered := make(map[int]int)
+		for _, ing := range ingresses {
+			if ing.Direction == RETROGRADE {
+				retrograde++
+			}
+			if g == ByGate {
+				entered[ing.To.Hex]++
+			}
+		}
+
+		if retrograde == 0 {
+			t.Errorf("%v: no retrograde ingresses in a retrograde loop", g)
+		}
+
+		if g == ByGate {
+			reentered := false
+			for _, n := range entered {
+				if n > 1 {
+					reentered = true
+				}
+			}
+			if !reentered {
+				t.Error("no gate is entered twice around the stations")
+			}
+		}
+	}
+}
+
+func TestFindIngressesAcrossZero(t *testing.T) {
+
+	// 0° - граница базы, проходим ее прямым и попятным движением
+	checkIngresses(t, fakeEphemeris{start: 359.99, speed: 1}, SUN, 0, int64(SEC_IN_1_DAY)/20, ByBase)
+	checkIngresses(t, fakeEphemeris{start: 0.01, speed: -1}, SUN, 0, int64(SEC_IN_1_DAY)/20, ByBase)
+}
+
+func TestFindIngressesAtStepEdge(t *testing.T) {
+
+	// шаг для Солнца по воротам - сутки, граница 25/17 ворот (3.875°) ровно через сутки
+	eph := fakeEphemeris{start: 2.875, speed: 1}
+	day := int64(SEC_IN_1_DAY)
+
+	if step := ingressStep(SUN, ByGate); step != day {
+		t.Fatalf("step %d, the test expects one day", step)
+	}
+
+	ingresses := FindIngresses(eph, SUN, 0, 3*day, ByGate)
+
+	if len(ingresses) != 1 {
+		t.Fatalf("got %d ingresses, want 1: %+v", len(ingresses), ingresses)
+	}
+	if ing := ingresses[0]; ing.SecFromJd2000 != day || ing.From.Hex != 25 || ing.To.Hex != 17 || ing.Direction != DIRECT {
+		t.Errorf("got %+v, want 25 -> 17 at %d", ing, day)
+	}
+}
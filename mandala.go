@@ -0,0 +1,142 @@
+package cd_consts_go
+
+import (
+	"fmt"
+	"math"
+)
+
+// баз в одной гексаграмме: 6 линий * 6 цветов * 6 тонов * 5 баз
+const basesInHex = 1080
+
+// гексаграммы по порядку на колесе, начиная с 25 ворот (WheelStartInDec)
+var HexWheel = [64]int{
+	25, 17, 21, 51, 42, 3, 27, 24,
+	2, 23, 8, 20, 16, 35, 45, 12,
+	15, 52, 39, 53, 62, 56, 31, 33,
+	7, 4, 29, 59, 40, 64, 47, 6,
+	46, 18, 48, 57, 32, 50, 28, 44,
+	1, 43, 14, 34, 9, 5, 26, 11,
+	10, 58, 38, 54, 61, 60, 41, 19,
+	13, 49, 30, 55, 37, 63, 22, 36,
+}
+
+// обратная таблица: ворота -> место на колесе и диапазон в градусах, from 1 to 64
+var (
+	hexWheelIndex [NUMBEROFGATES]int
+	hexRanges     [NUMBEROFGATES]HexRangeRAD
+)
+
+func init() {
+
+	for i, gate := range HexWheel {
+		hexWheelIndex[gate] = i
+
+		start := math.Mod(WheelStartInDec+float64(i)*OneHexInDec, 360.0)
+		hexRanges[gate] = HexRangeRAD{
+			StartDegree: start,
+			EndDegree:   math.Mod(start+OneHexInDec, 360.0),
+		}
+	}
+
+	if err := checkHexWheel(); err != nil {
+		panic(err)
+	}
+}
+
+// проверка колеса: все 64 ворот по одному разу, диапазоны идут встык без перекрытий
+// и совпадают с HexSortByDeg
+func checkHexWheel() error {
+
+	var seen [NUMBEROFGATES]bool
+	for _, gate := range HexWheel {
+		if gate < 1 || gate >= NUMBEROFGATES || seen[gate] {
+			return fmt.Errorf("HexWheel: bad or repeated gate %d", gate)
+		}
+		seen[gate] = true
+	}
+
+	for i, gate := range HexWheel {
+
+		next := HexWheel[(i+1)%len(HexWheel)]
+		if hexRanges[gate].EndDegree != hexRanges[next].StartDegree {
+			return fmt.Errorf("HexWheel: gap or overlap between gates %d and %d", gate, next)
+		}
+
+		if HexSortByDeg[gate] != [2]float64{hexRanges[gate].StartDegree, hexRanges[gate].EndDegree} {
+			return fmt.Errorf("HexSortByDeg: gate %d range %v does not match wheel", gate, HexSortByDeg[gate])
+		}
+	}
+
+	if len(HexSortByDeg) != len(HexWheel) {
+		return fmt.Errorf("HexSortByDeg: %d gates instead of %d", len(HexSortByDeg), len(HexWheel))
+	}
+
+	return nil
+}
+
+// диапазон ворот в градусах, начало включая, конец не включая
+func HexRange(gate int) HexRangeRAD {
+
+	if gate < 1 || gate >= NUMBEROFGATES {
+		return HexRangeRAD{}
+	}
+
+	return hexRanges[gate]
+}
+
+// место ворот на колесе, от 0 (25 ворота) до 63, -1 для несуществующих ворот
+func HexWheelIndex(gate int) int {
+
+	if gate < 1 || gate >= NUMBEROFGATES {
+		return -1
+	}
+
+	return hexWheelIndex[gate]
+}
+
+// номер базы от начала колеса (от 0 до 64*1080-1) по долготе в градусах
+func wheelBase(deg float64) int {
+
+	p := math.Mod(deg-WheelStartInDec, 360.0)
+	if p < 0 {
+		p += 360.0
+	}
+	// убираем шум от перевода градусы -> радианы -> градусы, чтобы границы попадали точно
+	p = math.Round(p*1e9) / 1e9
+
+	return int(p/OneBaseInDec+1e-6) % (len(HexWheel) * basesInHex)
+}
+
+// ворота по долготе в градусах за O(1)
+func HexByDegree(deg float64) int {
+	return HexWheel[wheelBase(deg)/basesInHex]
+}
+
+// заполняет HdStructure по эклиптической долготе в радианах.
+// Line, Color, Tone и Base - целые номера от 1, начало каждого интервала включается
+func NewHdStructure(longitudeRad float64) HdStructure {
+
+	deg := longitudeRad * RAD_TO_DEG
+
+	wb := wheelBase(deg)
+	index := wb / basesInHex
+	base := wb % basesInHex
+
+	passed := math.Mod(deg-WheelStartInDec-float64(index)*OneHexInDec, 360.0)
+	if passed < 0 {
+		passed += 360.0
+	}
+	if passed <= 0 || passed >= OneHexInDec {
+		// ровно на границе или чуть левее нее, округлено на границу
+		passed = 0
+	}
+
+	return HdStructure{
+		Hex:                   HexWheel[index],
+		Line:                  float64(base/180 + 1),
+		Color:                 float64(base%180/30 + 1),
+		Tone:                  float64(base%30/5 + 1),
+		Base:                  float64(base%5 + 1),
+		NumberOfPassedDegrees: passed,
+	}
+}
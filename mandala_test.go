@@ -0,0 +1,109 @@
+package cd_consts_go
+
+import (
+	"math"
+	"testing"
+)
+
+func checkHdStructure(t *testing.T, name string, deg float64, want HdStructure) {
+
+	t.Helper()
+
+	got := NewHdStructure(deg * RAD_RATIO)
+
+	if got.Hex != want.Hex || got.Line != want.Line || got.Color != want.Color ||
+		got.Tone != want.Tone || got.Base != want.Base ||
+		math.Abs(got.NumberOfPassedDegrees-want.NumberOfPassedDegrees) > 1e-8 {
+		t.Errorf("%s (%.10f°): got %+v, want %+v", name, deg, got, want)
+	}
+}
+
+func TestNewHdStructureBoundaries(t *testing.T) {
+
+	tests := []struct {
+		name string
+		deg  float64
+		want HdStructure
+	}{
+		{"wheel start, gate 25", 358.25, HdStructure{Hex: 25, Line: 1, Color: 1, Tone: 1, Base: 1}},
+		{"just below gate 25", 358.25 - 1e-7, HdStructure{Hex: 36, Line: 6, Color: 6, Tone: 6, Base: 5, NumberOfPassedDegrees: OneHexInDec - 1e-7}},
+		{"0°", 0, HdStructure{Hex: 25, Line: 2, Color: 6, Tone: 2, Base: 2, NumberOfPassedDegrees: 1.75}},
+		{"360°", 360, HdStructure{Hex: 25, Line: 2, Color: 6, Tone: 2, Base: 2, NumberOfPassedDegrees: 1.75}},
+		{"gate 25 end, gate 17 start", 3.875, HdStructure{Hex: 17, Line: 1, Color: 1, Tone: 1, Base: 1}},
+		{"just below 3.875°", 3.875 - 1e-7, HdStructure{Hex: 25, Line: 6, Color: 6, Tone: 6, Base: 5, NumberOfPassedDegrees: OneHexInDec - 1e-7}},
+		{"gate 12 end, gate 15 start", 88.25, HdStructure{Hex: 15, Line: 1, Color: 1, Tone: 1, Base: 1}},
+		{"gate 17 line 2", 3.875 + OneLineInDec, HdStructure{Hex: 17, Line: 2, Color: 1, Tone: 1, Base: 1, NumberOfPassedDegrees: OneLineInDec}},
+		{"gate 17 line 1 color 2", 3.875 + OneColorInDec, HdStructure{Hex: 17, Line: 1, Color: 2, Tone: 1, Base: 1, NumberOfPassedDegrees: OneColorInDec}},
+		{"gate 17 line 1 tone 2", 3.875 + OneToneInDec, HdStructure{Hex: 17, Line: 1, Color: 1, Tone: 2, Base: 1, NumberOfPassedDegrees: OneToneInDec}},
+		{"gate 17 line 1 base 2", 3.875 + OneBaseInDec, HdStructure{Hex: 17, Line: 1, Color: 1, Tone: 1, Base: 2, NumberOfPassedDegrees: OneBaseInDec}},
+	}
+
+	for _, tt := range tests {
+		checkHdStructure(t, tt.name, tt.deg, tt.want)
+	}
+}
+
+// начало каждой базы каждых ворот (а значит и каждой линии, цвета и тона) и точка чуть левее него
+func TestNewHdStructureEveryBase(t *testing.T) {
+
+	const below = 1e-7
+
+	for i, gate := range HexWheel {
+
+		prevGate := HexWheel[(i+len(HexWheel)-1)%len(HexWheel)]
+		start := WheelStartInDec + float64(i)*OneHexInDec
+
+		for b := 0; b < basesInHex; b++ {
+
+			deg := math.Mod(start+float64(b)*OneBaseInDec, 360.0)
+
+			want := HdStructure{
+				Hex:                   gate,
+				Line:                  float64(b/180 + 1),
+				Color:                 float64(b%180/30 + 1),
+				Tone:                  float64(b%30/5 + 1),
+				Base:                  float64(b%5 + 1),
+				NumberOfPassedDegrees: float64(b) * OneBaseInDec,
+			}
+			checkHdStructure(t, "base start", deg, want)
+
+			prev, prevHex := b-1, gate
+			if b == 0 {
+				prev, prevHex = basesInHex-1, prevGate
+			}
+			wantBelow := HdStructure{
+				Hex:                   prevHex,
+				Line:                  float64(prev/180 + 1),
+				Color:                 float64(prev%180/30 + 1),
+				Tone:                  float64(prev%30/5 + 1),
+				Base:                  float64(prev%5 + 1),
+				NumberOfPassedDegrees: float64(prev+1)*OneBaseInDec - below,
+			}
+			checkHdStructure(t, "just below base start", deg-below, wantBelow)
+		}
+	}
+}
+
+func TestHexByDegreeMatchesHexRange(t *testing.T) {
+
+	for gate := 1; gate < NUMBEROFGATES; gate++ {
+		if got := HexByDegree(HexRange(gate).StartDegree); got != gate {
+			t.Errorf("HexByDegree(start of %d) = %d", gate, got)
+		}
+	}
+}
+
+func TestHexWheelIndex(t *testing.T) {
+
+	for i, gate := range HexWheel {
+		if got := HexWheelIndex(gate); got != i {
+			t.Errorf("HexWheelIndex(%d) = %d, want %d", gate, got, i)
+		}
+	}
+
+	for _, gate := range []int{0, -1, 65, 100} {
+		if got := HexWheelIndex(gate); got != -1 {
+			t.Errorf("HexWheelIndex(%d) = %d, want -1", gate, got)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package cd_consts_go
+
+// чьи активации берутся в проекцию
+type ActivationSource int
+
+const (
+	BothSides ActivationSource = iota
+	PersonalityOnly
+	DesignOnly
+)
+
+func (as ActivationSource) String() string {
+	switch as {
+	case BothSides:
+		return "Personality and Design"
+	case PersonalityOnly:
+		return "Personality"
+	case DesignOnly:
+		return "Design"
+	}
+	return ""
+}
+
+// все 9 центров
+const AllCenters CenterSet = 1<<NUMBEROFCENTERS - 1
+
+// проекция карты на часть центров и ворот: те же HdStructure планет,
+// но учитываются только ворота центров из Centers и активации из Source.
+// Другие школьные проекции задаются своим набором центров
+type Projection struct {
+	Name    string
+	Centers CenterSet
+	Source  ActivationSource
+}
+
+// центры dream rave - карты сна: эго, G, селезенка и солнечное сплетение
+const DreamRaveCenters CenterSet = 1<<EGO | 1<<G | 1<<SPLEEN | 1<<EMO
+
+var (
+	PersonalityProjection = Projection{Name: "Personality", Centers: AllCenters, Source: PersonalityOnly}
+	DesignProjection      = Projection{Name: "Design", Centers: AllCenters, Source: DesignOnly}
+
+	// dream rave: активации личности и дизайна в 4 центрах сна,
+	// каналы остаются только между ними (25-51, 10-57, 44-26, 37-40)
+	DreamRaveProjection = Projection{Name: "Dream Rave", Centers: DreamRaveCenters, Source: BothSides}
+)
+
+// отдельная карта-проекция, исходная карта не меняется.
+// Ворота, каналы, центры, тип, авторитет и определение пересчитываются,
+// профиль, крест и переменные остаются от исходной карты
+func (hd *HdInfo) Project(p Projection) HdInfo {
+
+	view := *hd
+
+	for i := 1; i < NUMBEROFGATES; i++ {
+
+		g := hd.Gates[i]
+
+		if p.Source == DesignOnly {
+			g.Pers = 0
+		}
+		if p.Source == PersonalityOnly {
+			g.Des = 0
+		}
+		if !p.Centers.Has(GateCenter[i]) {
+			g.Pers, g.Des = 0, 0
+		}
+
+		g.Defined = g.Pers+g.Des > 0
+		view.Gates[i] = g
+	}
+
+	view.DefineCenters()
+	view.DeriveType()
+
+	return view
+}
+
+// карта на момент рождения и ее проекция
+func NewHdInfoProjection(eph Ephemeris, secFromJd2000 int64, p Projection) (HdInfo, HdInfo, error) {
+
+	hd, err := NewHdInfo(eph, secFromJd2000)
+	if err != nil {
+		return hd, HdInfo{}, err
+	}
+
+	return hd, hd.Project(p), nil
+}
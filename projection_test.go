@@ -0,0 +1,50 @@
+package cd_consts_go
+
+import "testing"
+
+func TestDreamRaveProjection(t *testing.T) {
+
+	// 25-51 (G - эго) и 7-31 (G - горло), 57 у дизайна без пары
+	hd := chartWithGates(25, 51, 7, 31)
+	hd.Gates[57].Des = 1
+	hd.Gates[57].Defined = true
+	hd.DefineCenters()
+	hd.DeriveType()
+
+	view := hd.Project(DreamRaveProjection)
+
+	if !view.Channels[23].Defined {
+		t.Error("25-51 must stay defined in the dream rave")
+	}
+	if view.Channels[14].Defined {
+		t.Error("7-31 must not be defined in the dream rave: the Throat is not a dream rave center")
+	}
+	if view.Gates[31].Defined || !view.Gates[7].Defined || !view.Gates[57].Defined {
+		t.Error("only gates of dream rave centers must stay activated")
+	}
+
+	if got := view.CenterSet(); got != CenterSet(0).With(G).With(EGO) {
+		t.Errorf("defined centers %b, want G and Ego", got)
+	}
+	if got := view.CenterSet().Intersect(DreamRaveCenters); got != view.CenterSet() {
+		t.Errorf("centers %b outside the dream rave", got)
+	}
+
+	// исходная карта не меняется
+	if !hd.Channels[14].Defined || !hd.Gates[31].Defined {
+		t.Error("Project changed the original chart")
+	}
+}
+
+func TestPersonalityProjection(t *testing.T) {
+
+	hd := chartWithGates(25, 51)
+	hd.Gates[51].Pers, hd.Gates[51].Des = 0, 1
+	hd.DefineCenters()
+
+	view := hd.Project(PersonalityProjection)
+
+	if view.Gates[51].Defined || view.Channels[23].Defined {
+		t.Error("a design-only gate must drop out of the personality projection")
+	}
+}
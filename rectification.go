@@ -0,0 +1,149 @@
+package cd_consts_go
+
+import (
+	"math"
+	"sort"
+)
+
+// смена линии одного тела внутри окна ректификации
+type LineChange struct {
+	SecFromJd2000 int64 // момент рождения, с которого действует новая линия
+	Design        bool  // false - личность, true - дизайн
+	Body          int
+	From          HdStructure
+	To            HdStructure
+}
+
+// интервал моментов рождения, на котором основные характеристики карты не меняются
+type RectificationInterval struct {
+	From int64 // включая
+	To   int64 // не включая, у последнего интервала - конец окна
+
+	Type       EnergyType
+	Authority  Authority
+	Profile    string
+	Definition Definition
+	Cross      Cross
+}
+
+type Rectification struct {
+	Intervals   []RectificationInterval
+	LineChanges []LineChange
+}
+
+// ректификация: для времени рождения approxSec +- uncertaintySec находит,
+// где меняются линии тел личности и дизайна, и интервалы, на которых
+// Type, Authority, Profile, Definition и Cross остаются одинаковыми
+func Rectify(eph Ephemeris, approxSec, uncertaintySec int64) (Rectification, error) {
+
+	var rect Rectification
+
+	from := approxSec - uncertaintySec
+	to := approxSec + uncertaintySec
+
+	designFrom, err := DesignTime(eph, from)
+	if err != nil {
+		return rect, err
+	}
+	designTo, err := DesignTime(eph, to)
+	if err != nil {
+		return rect, err
+	}
+
+	for body := 1; body < NUMBEROFPLANETS; body++ {
+
+		for _, in := range FindIngresses(eph, body, from, to, ByLine) {
+			rect.LineChanges = append(rect.LineChanges, LineChange{
+				SecFromJd2000: in.SecFromJd2000,
+				Body:          body,
+				From:          in.From,
+				To:            in.To,
+			})
+		}
+
+		for _, in := range FindIngresses(eph, body, designFrom, designTo, ByLine) {
+
+			birth, ok := birthTimeForDesign(eph, in.SecFromJd2000, from, to)
+			if !ok {
+				continue
+			}
+
+			rect.LineChanges = append(rect.LineChanges, LineChange{
+				SecFromJd2000: birth,
+				Design:        true,
+				Body:          body,
+				From:          in.From,
+				To:            in.To,
+			})
+		}
+	}
+
+	sort.SliceStable(rect.LineChanges, func(i, j int) bool {
+		return rect.LineChanges[i].SecFromJd2000 < rect.LineChanges[j].SecFromJd2000
+	})
+
+	// между сменами линий карта не меняется, так что достаточно посчитать ее в начале каждого куска
+	starts := []int64{from}
+	for _, lc := range rect.LineChanges {
+		if lc.SecFromJd2000 > starts[len(starts)-1] {
+			starts = append(starts, lc.SecFromJd2000)
+		}
+	}
+
+	for i, start := range starts {
+
+		end := to
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+
+		hd, err := NewHdInfo(eph, start)
+		if err != nil {
+			return rect, err
+		}
+
+		interval := RectificationInterval{
+			From:       start,
+			To:         end,
+			Type:       hd.Type,
+			Authority:  hd.Authority,
+			Profile:    hd.Profile,
+			Definition: hd.Definition,
+			Cross:      hd.Cross,
+		}
+
+		if n := len(rect.Intervals); n > 0 && sameRectification(rect.Intervals[n-1], interval) {
+			rect.Intervals[n-1].To = end
+			continue
+		}
+
+		rect.Intervals = append(rect.Intervals, interval)
+	}
+
+	return rect, nil
+}
+
+func sameRectification(a, b RectificationInterval) bool {
+	return a.Type == b.Type && a.Authority == b.Authority && a.Profile == b.Profile &&
+		a.Definition == b.Definition && a.Cross == b.Cross
+}
+
+// момент рождения от from до to, для которого designSec - момент дизайна:
+// Солнце в момент рождения на 88 градусов дальше, чем в designSec
+func birthTimeForDesign(eph Ephemeris, designSec, from, to int64) (int64, bool) {
+
+	target := math.Mod(eph.Longitude(SUN, designSec)+RAD_88_DEGREES, 2*PI)
+
+	// берем с запасом в сутки, границы окна проверяем ниже
+	crossings := FindLongitudeCrossings(eph, SUN, target, from-int64(SEC_IN_1_DAY), to+int64(SEC_IN_1_DAY))
+	if len(crossings) == 0 {
+		return 0, false
+	}
+
+	birth := crossings[0]
+	if birth < from || birth > to {
+		return 0, false
+	}
+
+	return birth, true
+}
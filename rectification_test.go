@@ -0,0 +1,132 @@
+package cd_consts_go
+
+import (
+	"math"
+	"testing"
+)
+
+// свои тестовые эфемериды у каждого тела, остальные тела - по умолчанию
+type bodyEphemeris struct {
+	fakeEphemeris
+	bodies map[int]fakeEphemeris
+}
+
+func (be bodyEphemeris) of(body int) fakeEphemeris {
+	if fe, ok := be.bodies[body]; ok {
+		return fe
+	}
+	return be.fakeEphemeris
+}
+
+func (be bodyEphemeris) Longitude(body int, sec int64) float64 {
+	return be.of(body).Longitude(body, sec)
+}
+
+func (be bodyEphemeris) LongitudeRate(body int, sec int64) float64 {
+	return be.of(body).LongitudeRate(body, sec)
+}
+
+func TestRectify(t *testing.T) {
+
+	day := int64(SEC_IN_1_DAY)
+
+	// Солнце и Земля 1°/сутки, Луна 13°/сутки, остальные тела стоят на месте:
+	// линии Луны меняются часто, а карта - только на смене ворот Луны и линий Солнца
+	eph := bodyEphemeris{
+		fakeEphemeris: fakeEphemeris{start: 200},
+		bodies: map[int]fakeEphemeris{
+			SUN:   {start: 100, speed: 1},
+			EARTH: {start: 280, speed: 1},
+			MOON:  {start: 0, speed: 13},
+		},
+	}
+
+	approx, uncertainty := 50*day, day/2
+	from, to := approx-uncertainty, approx+uncertainty
+
+	rect, err := Rectify(eph, approx, uncertainty)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rect.LineChanges) == 0 {
+		t.Fatal("no line changes in the window")
+	}
+
+	var design, moon int
+	for i, lc := range rect.LineChanges {
+
+		if i > 0 && lc.SecFromJd2000 < rect.LineChanges[i-1].SecFromJd2000 {
+			t.Errorf("line change %d at %d is before the previous one", i, lc.SecFromJd2000)
+		}
+		if lc.SecFromJd2000 < from || lc.SecFromJd2000 > to {
+			t.Errorf("line change %d at %d is outside the window", i, lc.SecFromJd2000)
+		}
+		if math.Ceil(lc.From.Line) == math.Ceil(lc.To.Line) && lc.From.Hex == lc.To.Hex {
+			t.Errorf("line change %d keeps line %v", i, lc.To)
+		}
+
+		// граница линии тела личности или, для дизайна, тела в момент дизайна
+		before, after := lc.SecFromJd2000-1, lc.SecFromJd2000
+		if lc.Design {
+			design++
+			if before, err = DesignTime(eph, lc.SecFromJd2000-60); err != nil {
+				t.Fatal(err)
+			}
+			if after, err = DesignTime(eph, lc.SecFromJd2000+60); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if unitOf(eph.Longitude(lc.Body, before), ByLine) == unitOf(eph.Longitude(lc.Body, after), ByLine) {
+			t.Errorf("line change %d (body %d, design %v) at %d is not a line boundary", i, lc.Body, lc.Design, lc.SecFromJd2000)
+		}
+
+		if lc.Body == MOON {
+			moon++
+		}
+	}
+
+	if design == 0 || moon < 10 {
+		t.Fatalf("want design and many Moon line changes, got %d design and %d Moon", design, moon)
+	}
+
+	if len(rect.Intervals) == 0 || rect.Intervals[0].From != from || rect.Intervals[len(rect.Intervals)-1].To != to {
+		t.Fatalf("intervals %+v do not cover [%d, %d)", rect.Intervals, from, to)
+	}
+	if len(rect.Intervals) >= len(rect.LineChanges) {
+		t.Errorf("%d intervals for %d line changes, want equal neighbours merged", len(rect.Intervals), len(rect.LineChanges))
+	}
+
+	for i, in := range rect.Intervals {
+
+		if i > 0 {
+			if in.From != rect.Intervals[i-1].To {
+				t.Errorf("interval %d starts at %d, previous ends at %d", i, in.From, rect.Intervals[i-1].To)
+			}
+			if sameRectification(in, rect.Intervals[i-1]) {
+				t.Errorf("intervals %d and %d are equal and not merged", i-1, i)
+			}
+		}
+
+		for _, sec := range []int64{in.From, (in.From + in.To) / 2} {
+
+			hd, err := NewHdInfo(eph, sec)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := RectificationInterval{
+				From:       in.From,
+				To:         in.To,
+				Type:       hd.Type,
+				Authority:  hd.Authority,
+				Profile:    hd.Profile,
+				Definition: hd.Definition,
+				Cross:      hd.Cross,
+			}
+			if got != in {
+				t.Errorf("chart at %d is %+v, interval %+v", sec, got, in)
+			}
+		}
+	}
+}
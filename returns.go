@@ -0,0 +1,114 @@
+package cd_consts_go
+
+import (
+	"errors"
+	"math"
+)
+
+// окно поиска возврата: период обращения с запасом, секунды
+var returnWindow = map[int]int64{
+	SUN:  370 * int64(SEC_IN_1_DAY),
+	MOON: 30 * int64(SEC_IN_1_DAY),
+}
+
+// разность долгот, приведенная к (-PI, PI]
+func angleDiff(a, b float64) float64 {
+
+	d := math.Mod(a-b, 2*PI)
+	if d > PI {
+		d -= 2 * PI
+	} else if d <= -PI {
+		d += 2 * PI
+	}
+
+	return d
+}
+
+// все моменты от from до to, когда долгота тела равна targetRad, с точностью до секунды.
+// Шаг - сутки, участки со станциями делятся на монотонные половины, так что
+// при попятном движении находятся все проходы
+func FindLongitudeCrossings(eph Ephemeris, body int, targetRad float64, from, to int64) []int64 {
+
+	var crossings []int64
+
+	t0 := from
+	rate0 := eph.LongitudeRate(body, t0)
+
+	for t0 < to {
+
+		t1 := min(t0+stationSearchStep, to)
+		rate1 := eph.LongitudeRate(body, t1)
+
+		if (rate0 < 0) != (rate1 < 0) {
+			station := bisectRateSign(eph, body, t0, t1, rate0)
+			crossings = appendCrossing(crossings, eph, body, targetRad, t0, station)
+			crossings = appendCrossing(crossings, eph, body, targetRad, station, t1)
+		} else {
+			crossings = appendCrossing(crossings, eph, body, targetRad, t0, t1)
+		}
+
+		t0, rate0 = t1, rate1
+	}
+
+	return crossings
+}
+
+func appendCrossing(crossings []int64, eph Ephemeris, body int, targetRad float64, t0, t1 int64) []int64 {
+
+	d0 := angleDiff(eph.Longitude(body, t0), targetRad)
+	d1 := angleDiff(eph.Longitude(body, t1), targetRad)
+
+	// смена знака далеко от цели - это переход через противоположную точку
+	if (d0 < 0) == (d1 < 0) || math.Abs(d0-d1) > PI {
+		return crossings
+	}
+
+	left, right := t0, t1
+	for right-left > 1 {
+		mid := left + (right-left)/2
+		if (angleDiff(eph.Longitude(body, mid), targetRad) < 0) == (d0 < 0) {
+			left = mid
+		} else {
+			right = mid
+		}
+	}
+
+	// первая секунда на или за целью; та же граница при повторном вызове не дублируется
+	if n := len(crossings); n > 0 && crossings[n-1] == right {
+		return crossings
+	}
+
+	return append(crossings, right)
+}
+
+// следующий (forward) или предыдущий возврат Солнца или Луны к натальной долготе natalRad
+// относительно момента fromSec. Возвращает полную карту неба на момент возврата
+func FindReturn(eph Ephemeris, body int, natalRad float64, fromSec int64, forward bool) (HdObjects, error) {
+
+	window, ok := returnWindow[body]
+	if !ok {
+		return HdObjects{}, errors.New("FindReturn: only Sun and Moon returns are supported")
+	}
+
+	if forward {
+		if c := FindLongitudeCrossings(eph, body, natalRad, fromSec+1, fromSec+window); len(c) > 0 {
+			return NewHdObjects(eph, c[0]), nil
+		}
+	} else {
+		if c := FindLongitudeCrossings(eph, body, natalRad, fromSec-window, fromSec-1); len(c) > 0 {
+			return NewHdObjects(eph, c[len(c)-1]), nil
+		}
+	}
+
+	return HdObjects{}, errors.New("FindReturn: no return found in the search window")
+}
+
+// соляр: возврат Солнца к его положению в натальной карте
+func SolarReturn(eph Ephemeris, natal *HdObjects, fromSec int64, forward bool) (HdObjects, error) {
+	return FindReturn(eph, SUN, natal.Planet[SUN].Longitude, fromSec, forward)
+}
+
+// лунар: возврат Луны к ее положению в натальной карте
+func LunarReturn(eph Ephemeris, natal *HdObjects, fromSec int64, forward bool) (HdObjects, error) {
+	return FindReturn(eph, MOON, natal.Planet[MOON].Longitude, fromSec, forward)
+}
@@ -0,0 +1,85 @@
+package cd_consts_go
+
+import "testing"
+
+// все проходы через target с шагом sample секунд: время первой точки на или за целью
+func bruteCrossings(eph Ephemeris, body int, target float64, from, to, sample int64) []int64 {
+
+	var times []int64
+
+	prev := angleDiff(eph.Longitude(body, from), target)
+	for t := from + sample; t <= to; t += sample {
+		d := angleDiff(eph.Longitude(body, t), target)
+		if (prev < 0) != (d < 0) && d-prev < PI && prev-d < PI {
+			times = append(times, t)
+		}
+		prev = d
+	}
+
+	return times
+}
+
+func TestFindLongitudeCrossingsRetrogradeAcrossZero(t *testing.T) {
+
+	// петля между ~349° и ~11°, 0° проходится трижды: вперед, назад и снова вперед
+	eph := loopEphemeris(310)
+	period := int64(100 * SEC_IN_1_DAY)
+
+	const sample = 60
+
+	got := FindLongitudeCrossings(eph, MERCURY, 0, 0, period)
+	want := bruteCrossings(eph, MERCURY, 0, 0, period, sample)
+
+	if len(want) != 3 {
+		t.Fatalf("brute force finds %d crossings, the test expects 3", len(want))
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d crossings, want %d: %v", len(got), len(want), got)
+	}
+
+	for i := range got {
+		if got[i] <= want[i]-sample || got[i] > want[i] {
+			t.Errorf("crossing %d at %d, brute force puts it in (%d, %d]", i, got[i], want[i]-sample, want[i])
+		}
+	}
+}
+
+func TestFindLongitudeCrossingsLinear(t *testing.T) {
+
+	eph := fakeEphemeris{start: 350, speed: 1}
+	day := int64(SEC_IN_1_DAY)
+
+	tests := []struct {
+		targetDeg float64
+		want      int64
+	}{
+		{0, 10 * day},
+		{355, 5 * day},
+		{5, 15 * day},
+	}
+
+	for _, tt := range tests {
+		got := FindLongitudeCrossings(eph, SUN, tt.targetDeg*RAD_RATIO, 0, 20*day)
+		if len(got) != 1 || got[0] < tt.want-1 || got[0] > tt.want+1 {
+			t.Errorf("target %v°: got %v, want one crossing at %d", tt.targetDeg, got, tt.want)
+		}
+	}
+
+	// противоположная точка (170°) в окне не пересекается и не должна давать ложных проходов
+	if got := FindLongitudeCrossings(eph, SUN, 170*RAD_RATIO, 0, 20*day); len(got) != 0 {
+		t.Errorf("target 170°: got %v, want none", got)
+	}
+}
+
+func TestFindLongitudeCrossingsAtStepEdge(t *testing.T) {
+
+	// цель 0° достигается ровно на границе суточного шага
+	eph := fakeEphemeris{start: 359, speed: 1}
+	day := int64(SEC_IN_1_DAY)
+
+	got := FindLongitudeCrossings(eph, SUN, 0, 0, 3*day)
+
+	if len(got) != 1 || got[0] != day {
+		t.Errorf("got %v, want exactly one crossing at %d", got, day)
+	}
+}
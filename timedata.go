@@ -0,0 +1,69 @@
+package cd_consts_go
+
+import "math"
+
+// TimeData для момента в Ephemeries time, UtcTime получаем вычитанием Дельта T
+func NewTimeData(secFromJd2000 int64) TimeData {
+
+	utc := float64(secFromJd2000) - DeltaTForSec(secFromJd2000)
+
+	return TimeData{
+		UtcTime:       SecToGregDate(int64(math.Round(utc))),
+		TypeOfTyme:    0,
+		SecFromJd2000: secFromJd2000,
+	}
+}
+
+// дата по секундам от JD2000 в той же шкале времени, григорианский календарь (Meeus, гл. 7)
+func SecToGregDate(secFromJd2000 int64) GregDate {
+
+	// JD2000 - полдень, а сутки в дате начинаются с полуночи
+	secFromMidnight := secFromJd2000 + int64(SEC_IN_1_DAY)/2
+	days := floorDiv(secFromMidnight, int64(SEC_IN_1_DAY))
+	secOfDay := int(secFromMidnight - days*int64(SEC_IN_1_DAY))
+
+	// целое JD полуночи + 0.5
+	z := days + JD2000
+
+	a := z
+	if z >= 2299161 {
+		alpha := floorDiv(100*z-186721625, 3652425)
+		a = z + 1 + alpha - floorDiv(alpha, 4)
+	}
+
+	b := a + 1524
+	c := floorDiv(100*b-12210, 36525)
+	d := floorDiv(36525*c, 100)
+	e := floorDiv(10000*(b-d), 306001)
+
+	day := b - d - floorDiv(306001*e, 10000)
+
+	month := e - 1
+	if e >= 14 {
+		month = e - 13
+	}
+
+	year := c - 4716
+	if month <= 2 {
+		year = c - 4715
+	}
+
+	return GregDate{
+		Year:    int(year),
+		Month:   int(month),
+		Day:     int(day),
+		Hour:    secOfDay / 3600,
+		Minutes: secOfDay % 3600 / 60,
+		Seconds: secOfDay % 60,
+	}
+}
+
+func floorDiv(a, b int64) int64 {
+
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+
+	return q
+}
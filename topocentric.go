@@ -0,0 +1,164 @@
+package cd_consts_go
+
+import "math"
+
+const (
+	// WGS84
+	WGS84_A = 6378.137 // экваториальный радиус, km
+	WGS84_F = 1 / 298.257223563
+
+	// угловая скорость вращения Земли, радиан в секунду
+	EARTH_ROTATION_RATE = 7.292115e-5
+)
+
+// место наблюдателя на эллипсоиде WGS84
+type Observer struct {
+	Latitude  float64 // в градусах, север положительный
+	Longitude float64 // в градусах, восток положительный
+	Height    float64 // над эллипсоидом, в метрах
+}
+
+// Earth Rotation Angle (IAU 2000) в радианах, ut1 - секунды UT1 от JD2000
+func EarthRotationAngle(ut1FromJd2000 float64) float64 {
+
+	du := ut1FromJd2000 / float64(SEC_IN_1_DAY)
+
+	// дробную часть суток берем отдельно, чтобы не терять точность
+	era := 2 * PI * (math.Mod(du, 1.0) + 0.7790572732640 + 0.00273781191135448*du)
+
+	era = math.Mod(era, 2*PI)
+	if era < 0 {
+		era += 2 * PI
+	}
+
+	return era
+}
+
+// Greenwich Mean Sidereal Time (IAU 2006) в радианах через ERA,
+// secFromJd2000 - Ephemeries time, UT1 получаем через Дельта T
+func Gmst(secFromJd2000 int64) float64 {
+
+	ut1 := float64(secFromJd2000) - DeltaTForSec(secFromJd2000)
+
+	// юлианские столетия TT
+	t := float64(secFromJd2000) / float64(SEC_IN_1_DAY) / 36525.0
+
+	gmst := EarthRotationAngle(ut1) +
+		(0.014506+4612.156534*t+1.3915817*t*t-0.00000044*t*t*t-0.000029956*t*t*t*t)*RAD_PER_ARCSECONDS
+
+	gmst = math.Mod(gmst, 2*PI)
+	if gmst < 0 {
+		gmst += 2 * PI
+	}
+
+	return gmst
+}
+
+// положение наблюдателя относительно центра Земли в земной системе (ITRS), km и km/s
+func (obs Observer) terrestrial() Position {
+
+	lat := obs.Latitude * RAD_RATIO
+	lon := obs.Longitude * RAD_RATIO
+	h := obs.Height / 1000.0
+
+	e2 := WGS84_F * (2 - WGS84_F)
+	sinLat, cosLat := math.Sincos(lat)
+	sinLon, cosLon := math.Sincos(lon)
+
+	n := WGS84_A / math.Sqrt(1-e2*sinLat*sinLat)
+
+	x := (n + h) * cosLat * cosLon
+	y := (n + h) * cosLat * sinLon
+	z := (n*(1-e2) + h) * sinLat
+
+	return Position{
+		X: x,
+		Y: y,
+		Z: z,
+
+		VelocityX: -EARTH_ROTATION_RATE * y,
+		VelocityY: EARTH_ROTATION_RATE * x,
+	}
+}
+
+// положение наблюдателя относительно центра Земли в экваториальных координатах J2000 (как в SPK)
+// на момент secFromJd2000. Поворот на звездное время дает среднее равноденствие даты,
+// оттуда прецессией переводим к J2000. Нутацией пренебрегаем: наблюдатель смещается
+// не больше чем на ~0.6 km, что с Луны меньше 0.5 угловой секунды
+func (obs Observer) GeocentricPosition(secFromJd2000 int64) Position {
+
+	ter := obs.terrestrial()
+	sinT, cosT := math.Sincos(Gmst(secFromJd2000))
+
+	ofDate := Position{
+		X: ter.X*cosT - ter.Y*sinT,
+		Y: ter.X*sinT + ter.Y*cosT,
+		Z: ter.Z,
+
+		VelocityX: ter.VelocityX*cosT - ter.VelocityY*sinT,
+		VelocityY: ter.VelocityX*sinT + ter.VelocityY*cosT,
+		VelocityZ: ter.VelocityZ,
+	}
+
+	return PrecessToJ2000(ofDate, secFromJd2000)
+}
+
+// матрица прецессии IAU 1976 (Lieske) от J2000 к среднему равноденствию даты
+func precessionMatrix(secFromJd2000 int64) [3][3]float64 {
+
+	// юлианские столетия TT
+	t := float64(secFromJd2000) / float64(SEC_IN_1_DAY) / 36525.0
+
+	zeta := (2306.2181*t + 0.30188*t*t + 0.017998*t*t*t) * RAD_PER_ARCSECONDS
+	z := (2306.2181*t + 1.09468*t*t + 0.018203*t*t*t) * RAD_PER_ARCSECONDS
+	theta := (2004.3109*t - 0.42665*t*t - 0.041833*t*t*t) * RAD_PER_ARCSECONDS
+
+	sinZeta, cosZeta := math.Sincos(zeta)
+	sinZ, cosZ := math.Sincos(z)
+	sinTheta, cosTheta := math.Sincos(theta)
+
+	return [3][3]float64{
+		{cosZeta*cosTheta*cosZ - sinZeta*sinZ, -sinZeta*cosTheta*cosZ - cosZeta*sinZ, -sinTheta * cosZ},
+		{cosZeta*cosTheta*sinZ + sinZeta*cosZ, -sinZeta*cosTheta*sinZ + cosZeta*cosZ, -sinTheta * sinZ},
+		{cosZeta * sinTheta, -sinZeta * sinTheta, cosTheta},
+	}
+}
+
+// экваториальный вектор от среднего равноденствия даты к J2000 (обратная прецессия)
+func PrecessToJ2000(p Position, secFromJd2000 int64) Position {
+
+	m := precessionMatrix(secFromJd2000)
+
+	// обратная матрица поворота - транспонированная
+	rotate := func(x, y, z float64) (float64, float64, float64) {
+		return m[0][0]*x + m[1][0]*y + m[2][0]*z,
+			m[0][1]*x + m[1][1]*y + m[2][1]*z,
+			m[0][2]*x + m[1][2]*y + m[2][2]*z
+	}
+
+	var out Position
+	out.X, out.Y, out.Z = rotate(p.X, p.Y, p.Z)
+	out.VelocityX, out.VelocityY, out.VelocityZ = rotate(p.VelocityX, p.VelocityY, p.VelocityZ)
+
+	return out
+}
+
+// топоцентрическое положение тела по его геоцентрическому экваториальному положению
+func (obs Observer) Topocentric(geo Position, secFromJd2000 int64) Position {
+	return geo.Sub(obs.GeocentricPosition(secFromJd2000))
+}
+
+// топоцентрические эклиптические полярные координаты
+func (obs Observer) TopocentricPolar(geo Position, secFromJd2000 int64) PolarPosition {
+	return EquatorialToEcliptic(obs.Topocentric(geo, secFromJd2000), MED_EPS).Polar()
+}
+
+// топоцентрическое положение, если наблюдатель задан, иначе геоцентрическое
+func ApparentPosition(geo Position, obs *Observer, secFromJd2000 int64) Position {
+
+	if obs == nil {
+		return geo
+	}
+
+	return obs.Topocentric(geo, secFromJd2000)
+}
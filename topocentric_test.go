@@ -0,0 +1,102 @@
+package cd_consts_go
+
+import (
+	"math"
+	"testing"
+)
+
+// Meeus, Astronomical Algorithms, пример 21.b: θ Persei,
+// среднее положение на 2028 Nov 13.19 TD (JD 2462088.69) и на J2000 (с учетом собственного движения)
+func TestPrecessToJ2000(t *testing.T) {
+
+	sec := int64(math.Round((2462088.69 - JD2000) * float64(SEC_IN_1_DAY)))
+
+	ra, dec := 41.547214*RAD_RATIO, 49.348483*RAD_RATIO
+	ofDate := Position{
+		X: math.Cos(dec) * math.Cos(ra),
+		Y: math.Cos(dec) * math.Sin(ra),
+		Z: math.Sin(dec),
+	}
+
+	p := PrecessToJ2000(ofDate, sec)
+
+	gotRa := math.Atan2(p.Y, p.X) * RAD_TO_DEG
+	gotDec := math.Asin(p.Z) * RAD_TO_DEG
+
+	if math.Abs(gotRa-41.054063) > 1e-5 || math.Abs(gotDec-49.227750) > 1e-5 {
+		t.Errorf("got RA %.6f Dec %.6f, want RA 41.054063 Dec 49.227750", gotRa, gotDec)
+	}
+}
+
+func TestObserverGeocentricPositionRadius(t *testing.T) {
+
+	// на экваторе на уровне эллипсоида расстояние до центра - экваториальный радиус при любом времени
+	obs := Observer{Latitude: 0, Longitude: 30}
+
+	for _, sec := range []int64{0, 820497600, -1577880000} {
+		p := obs.GeocentricPosition(sec)
+		if r := math.Sqrt(p.X*p.X + p.Y*p.Y + p.Z*p.Z); math.Abs(r-WGS84_A) > 1e-6 {
+			t.Errorf("sec %d: radius %.9f km, want %.9f", sec, r, WGS84_A)
+		}
+	}
+}
+
+func TestNewHdObjectsTopocentric(t *testing.T) {
+
+	eph := vectorEphemeris{
+		fakeEphemeris: fakeEphemeris{start: 100, speed: 1},
+		distance:      map[int]float64{MOON: 384400, SUN: AU},
+	}
+	for body := MERCURY; body <= PLUTO; body++ {
+		eph.distance[body] = 5 * AU
+	}
+
+	// наблюдатель на экваторе, момент выбран произвольно
+	obs := &Observer{Latitude: 0, Longitude: 60}
+	sec := int64(820497600)
+
+	geo := NewHdObjects(eph, sec)
+	topo, err := NewHdObjectsWithOptions(eph, sec, ChartOptions{Mode: Topocentric, Observer: obs})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if topo.Mode != Topocentric || geo.Mode != Geocentric {
+		t.Errorf("modes %v and %v, want Topocentric and Geocentric", topo.Mode, geo.Mode)
+	}
+
+	shift := func(body int) float64 {
+		return math.Abs(angleDiff(topo.Planet[body].Longitude, geo.Planet[body].Longitude)) * RAD_TO_DEG
+	}
+
+	// горизонтальный параллакс Луны ~0.95°, Солнца ~8.8"
+	if s := shift(MOON); s == 0 || s > 0.96 {
+		t.Errorf("Moon parallax %.4f°, want between 0 and 0.96°", s)
+	}
+	if s := shift(SUN); s > 9.0/3600 {
+		t.Errorf("Sun parallax %.6f°, want under 9\"", s)
+	}
+	if shift(NORTHNODE) != 0 || shift(SOUTHNODE) != 0 {
+		t.Error("lunar nodes must not change")
+	}
+	if d := angleDiff(topo.Planet[EARTH].Longitude, topo.Planet[SUN].Longitude+PI); math.Abs(d) > 1e-12 {
+		t.Errorf("Earth is %.3g rad off the point opposite the Sun", d)
+	}
+	if topo.Planet[MOON].Hex != NewHdStructure(topo.Planet[MOON].Longitude).Hex {
+		t.Error("HdStructure was not recalculated after the parallax shift")
+	}
+}
+
+func TestNewHdObjectsTopocentricErrors(t *testing.T) {
+
+	obs := &Observer{Latitude: 50, Longitude: 30}
+
+	if _, err := NewHdObjectsWithOptions(fakeEphemeris{speed: 1}, 0, ChartOptions{Mode: Topocentric, Observer: obs}); err == nil {
+		t.Error("want an error for ephemeris without VectorSupport")
+	}
+
+	eph := vectorEphemeris{fakeEphemeris: fakeEphemeris{speed: 1}}
+	if _, err := NewHdObjectsWithOptions(eph, 0, ChartOptions{Mode: Topocentric}); err == nil {
+		t.Error("want an error for a topocentric chart without an observer")
+	}
+}
@@ -0,0 +1,62 @@
+package cd_consts_go
+
+// транзит: небо на момент времени, наложенное на сохраненную карту
+type Transit struct {
+	Sky HdObjects
+
+	// карта с добавленными воротами неба. Pers и Des остаются от карты,
+	// ворота транзита отмечены только Defined
+	Overlay HdInfo
+
+	NewGates    []int    // ворота неба, которых не было в карте
+	NewChannels []int    // каналы, которые транзит замыкает
+	NewCenters  []Center // центры, которые транзит определяет
+}
+
+// ворота, активированные небом (13 тел карты, без SSB и Хирона)
+func (hdo *HdObjects) ActivatedGates() [NUMBEROFGATES]bool {
+
+	var gates [NUMBEROFGATES]bool
+	for body := 1; body < NUMBEROFPLANETS; body++ {
+		if hex := hdo.Planet[body].Hex; hex > 0 && hex < NUMBEROFGATES {
+			gates[hex] = true
+		}
+	}
+
+	return gates
+}
+
+// накладывает небо на момент secFromJd2000 на карту natal,
+// у natal должны быть рассчитаны ворота, каналы и центры
+func NewTransit(natal *HdInfo, eph Ephemeris, secFromJd2000 int64) Transit {
+
+	tr := Transit{
+		Sky:     NewHdObjects(eph, secFromJd2000),
+		Overlay: *natal,
+	}
+
+	sky := tr.Sky.ActivatedGates()
+	for i := 1; i < NUMBEROFGATES; i++ {
+		if sky[i] && !natal.Gates[i].Defined {
+			tr.Overlay.Gates[i].Defined = true
+			tr.NewGates = append(tr.NewGates, i)
+		}
+	}
+
+	tr.Overlay.DefineCenters()
+	tr.Overlay.DeriveType()
+
+	for i := 1; i < NUMBEROFCHANNELS; i++ {
+		if tr.Overlay.Channels[i].Defined && !natal.Channels[i].Defined {
+			tr.NewChannels = append(tr.NewChannels, i)
+		}
+	}
+
+	for c := Center(HEAD); c <= EMO; c++ {
+		if tr.Overlay.Centers.Center[c] && !natal.Centers.Center[c] {
+			tr.NewCenters = append(tr.NewCenters, c)
+		}
+	}
+
+	return tr
+}
@@ -0,0 +1,44 @@
+package cd_consts_go
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewTransit(t *testing.T) {
+
+	// все тела неба в 0°, это ворота 25. Они замыкают висящие ворота 51 карты в канал 25-51 (Initiation)
+	eph := fakeEphemeris{start: 0}
+	natal := chartWithGates(51)
+	before := *natal
+
+	tr := NewTransit(natal, eph, 0)
+
+	sky := tr.Sky.ActivatedGates()
+	for i := 1; i < NUMBEROFGATES; i++ {
+		if sky[i] != (i == 25) {
+			t.Fatalf("sky gate %d activated %v, want only 25", i, sky[i])
+		}
+	}
+
+	if want := []int{25}; !reflect.DeepEqual(tr.NewGates, want) {
+		t.Errorf("new gates %v, want %v", tr.NewGates, want)
+	}
+	if want := []int{23}; !reflect.DeepEqual(tr.NewChannels, want) {
+		t.Errorf("new channels %v, want %v", tr.NewChannels, want)
+	}
+	if want := []Center{G, EGO}; !reflect.DeepEqual(tr.NewCenters, want) {
+		t.Errorf("new centers %v, want %v", tr.NewCenters, want)
+	}
+
+	if !tr.Overlay.Channels[23].Defined || tr.Overlay.Gates[25].Pers != 0 {
+		t.Errorf("overlay channel 23 %v, gate 25 %+v", tr.Overlay.Channels[23].Defined, tr.Overlay.Gates[25])
+	}
+
+	if !reflect.DeepEqual(*natal, before) {
+		t.Error("NewTransit modified the natal chart")
+	}
+	if natal.Gates[25].Defined || natal.Channels[23].Defined || natal.Centers.Center[G] {
+		t.Error("natal chart got transit activations")
+	}
+}
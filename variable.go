@@ -0,0 +1,294 @@
+package cd_consts_go
+
+import (
+	"fmt"
+	"math"
+)
+
+// направление стрелки: тона 1-3 - левая, 4-6 - правая. 0 - не рассчитана
+type Arrow int
+
+const (
+	LeftArrow Arrow = iota + 1
+	RightArrow
+)
+
+func (a Arrow) String() string {
+	switch a {
+	case LeftArrow:
+		return "L"
+	case RightArrow:
+		return "R"
+	}
+	return ""
+}
+
+func arrowByTone(tone int) Arrow {
+	switch {
+	case tone >= 1 && tone <= 3:
+		return LeftArrow
+	case tone >= 4 && tone <= 6:
+		return RightArrow
+	}
+	return 0
+}
+
+// четыре стрелки: Digestion и Environment - дизайн, Motivation и Perspective - личность
+type Variable struct {
+	Digestion   Arrow // Солнце дизайна, вверху слева
+	Environment Arrow // узлы дизайна, внизу слева
+	Motivation  Arrow // Солнце личности, вверху справа
+	Perspective Arrow // узлы личности, внизу справа
+}
+
+// в привычной записи, например "PLR DLL"
+func (v Variable) String() string {
+
+	if v == (Variable{}) {
+		return ""
+	}
+
+	return "P" + v.Motivation.String() + v.Perspective.String() + " D" + v.Digestion.String() + v.Environment.String()
+}
+
+// в JSON пишется строкой, как раньше
+func (v Variable) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *Variable) UnmarshalText(text []byte) error {
+
+	s := string(text)
+	if s == "" {
+		*v = Variable{}
+		return nil
+	}
+
+	arrow := func(b byte) Arrow {
+		switch b {
+		case 'L':
+			return LeftArrow
+		case 'R':
+			return RightArrow
+		}
+		return 0
+	}
+
+	if len(s) != 7 || s[0] != 'P' || s[3] != ' ' || s[4] != 'D' {
+		return fmt.Errorf("unknown variable %q", s)
+	}
+
+	parsed := Variable{
+		Motivation:  arrow(s[1]),
+		Perspective: arrow(s[2]),
+		Digestion:   arrow(s[5]),
+		Environment: arrow(s[6]),
+	}
+	if parsed.Motivation == 0 || parsed.Perspective == 0 || parsed.Digestion == 0 || parsed.Environment == 0 {
+		return fmt.Errorf("unknown variable %q", s)
+	}
+
+	*v = parsed
+
+	return nil
+}
+
+// названия по цвету (from 1 to 6) и пары левый/правый по стрелке
+var (
+	determinationNames = [7]string{"", "Appetite", "Taste", "Thirst", "Touch", "Sound", "Light"}
+	determinationSides = [7][2]string{{}, {"Consecutive", "Alternating"}, {"Open", "Closed"},
+		{"Hot", "Cold"}, {"Calm", "Nervous"}, {"High", "Low"}, {"Direct", "Indirect"}}
+
+	environmentNames = [7]string{"", "Caves", "Markets", "Kitchens", "Mountains", "Valleys", "Shores"}
+	environmentSides = [7][2]string{{}, {"Selective", "Blending"}, {"Internal", "External"},
+		{"Wet", "Dry"}, {"Active", "Passive"}, {"Narrow", "Wide"}, {"Natural", "Artificial"}}
+
+	motivationNames  = [7]string{"", "Fear", "Hope", "Desire", "Need", "Guilt", "Innocence"}
+	perspectiveNames = [7]string{"", "Survival", "Possibility", "Power", "Wanting", "Probability", "Personal"}
+
+	// стрелка у мотивации и перспективы, у которых нет своих пар
+	arrowSides = [7][2]string{{}, {"Left", "Right"}, {"Left", "Right"},
+		{"Left", "Right"}, {"Left", "Right"}, {"Left", "Right"}, {"Left", "Right"}}
+
+	// по тону Солнца дизайна, from 1 to 6
+	cognitionNames = [7]string{"", "Smell", "Taste", "Outer Vision", "Inner Vision", "Feeling", "Touch"}
+)
+
+// пищеварение (determination) по цвету Солнца дизайна и стрелке его тона
+type Determination struct {
+	Color int
+	Arrow Arrow
+}
+
+func (d Determination) String() string {
+	return colorLabel(determinationNames, &determinationSides, d.Color, d.Arrow)
+}
+
+func (d Determination) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Determination) UnmarshalText(text []byte) error {
+	return unmarshalColorLabel(text, func(color int, arrow Arrow) string {
+		return Determination{Color: color, Arrow: arrow}.String()
+	}, &d.Color, &d.Arrow, "determination")
+}
+
+// среда по цвету узлов дизайна и стрелке их тона
+type Environment struct {
+	Color int
+	Arrow Arrow
+}
+
+func (e Environment) String() string {
+	return colorLabel(environmentNames, &environmentSides, e.Color, e.Arrow)
+}
+
+func (e Environment) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+func (e *Environment) UnmarshalText(text []byte) error {
+	return unmarshalColorLabel(text, func(color int, arrow Arrow) string {
+		return Environment{Color: color, Arrow: arrow}.String()
+	}, &e.Color, &e.Arrow, "environment")
+}
+
+// когнитивность по тону Солнца дизайна
+type Cognition int
+
+func (c Cognition) String() string {
+	if c < 1 || c > 6 {
+		return ""
+	}
+	return cognitionNames[c]
+}
+
+func (c Cognition) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Cognition) UnmarshalText(text []byte) error {
+
+	for i, name := range cognitionNames {
+		if name == string(text) {
+			*c = Cognition(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown cognition %q", text)
+}
+
+// мотивация по цвету Солнца личности и стрелке его тона
+type Motivation struct {
+	Color int
+	Arrow Arrow
+}
+
+func (m Motivation) String() string {
+	return colorLabel(motivationNames, nil, m.Color, m.Arrow)
+}
+
+func (m Motivation) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Motivation) UnmarshalText(text []byte) error {
+	return unmarshalColorLabel(text, func(color int, arrow Arrow) string {
+		return Motivation{Color: color, Arrow: arrow}.String()
+	}, &m.Color, &m.Arrow, "motivation")
+}
+
+// перспектива (взгляд) по цвету узлов личности и стрелке их тона
+type Perspective struct {
+	Color int
+	Arrow Arrow
+}
+
+func (p Perspective) String() string {
+	return colorLabel(perspectiveNames, nil, p.Color, p.Arrow)
+}
+
+func (p Perspective) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Perspective) UnmarshalText(text []byte) error {
+	return unmarshalColorLabel(text, func(color int, arrow Arrow) string {
+		return Perspective{Color: color, Arrow: arrow}.String()
+	}, &p.Color, &p.Arrow, "perspective")
+}
+
+// разбор названия, которое пишет String()
+func unmarshalColorLabel(text []byte, label func(color int, arrow Arrow) string, color *int, arrow *Arrow, what string) error {
+
+	if len(text) == 0 {
+		*color, *arrow = 0, 0
+		return nil
+	}
+
+	for c := 1; c <= 6; c++ {
+		for _, a := range []Arrow{0, LeftArrow, RightArrow} {
+			if label(c, a) == string(text) {
+				*color, *arrow = c, a
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("unknown %s %q", what, text)
+}
+
+// без своих пар (sides == nil) стрелка пишется как Left/Right
+func colorLabel(names [7]string, sides *[7][2]string, color int, arrow Arrow) string {
+
+	if color < 1 || color > 6 {
+		return ""
+	}
+
+	if sides == nil {
+		sides = &arrowSides
+	}
+
+	switch arrow {
+	case LeftArrow:
+		return names[color] + " - " + sides[color][0]
+	case RightArrow:
+		return names[color] + " - " + sides[color][1]
+	}
+
+	return names[color]
+}
+
+func colorAndTone(hs HdStructure) (int, int) {
+	return int(math.Ceil(hs.Color)), int(math.Ceil(hs.Tone))
+}
+
+// заполняет Variable, Phs и Psychology по цвету и тону Солнца и северного узла личности и дизайна,
+// HdStructure планет должны быть уже рассчитаны
+func (hd *HdInfo) DeriveVariable() {
+
+	dSunColor, dSunTone := colorAndTone(hd.Design.Planet[SUN].HdStructure)
+	dNodeColor, dNodeTone := colorAndTone(hd.Design.Planet[NORTHNODE].HdStructure)
+	pSunColor, pSunTone := colorAndTone(hd.Personality.Planet[SUN].HdStructure)
+	pNodeColor, pNodeTone := colorAndTone(hd.Personality.Planet[NORTHNODE].HdStructure)
+
+	hd.Variable = Variable{
+		Digestion:   arrowByTone(dSunTone),
+		Environment: arrowByTone(dNodeTone),
+		Motivation:  arrowByTone(pSunTone),
+		Perspective: arrowByTone(pNodeTone),
+	}
+
+	hd.Phs = Phs{
+		Theme:     Environment{Color: dNodeColor, Arrow: hd.Variable.Environment},
+		NutrType:  Determination{Color: dSunColor, Arrow: hd.Variable.Digestion},
+		Cognition: Cognition(dSunTone),
+	}
+
+	hd.Psychology = Psychology{
+		Motivation: Motivation{Color: pSunColor, Arrow: hd.Variable.Motivation},
+		Mind:       Perspective{Color: pNodeColor, Arrow: hd.Variable.Perspective},
+	}
+}
@@ -0,0 +1,65 @@
+package cd_consts_go
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestVariableJSONLabels(t *testing.T) {
+
+	type chart struct {
+		Phs
+		Variable Variable
+		Psychology
+	}
+
+	in := chart{
+		Phs: Phs{
+			Theme:     Environment{Color: 2, Arrow: RightArrow},
+			NutrType:  Determination{Color: 1, Arrow: LeftArrow},
+			Cognition: Cognition(2),
+		},
+		Variable:   Variable{Digestion: LeftArrow, Environment: LeftArrow, Motivation: LeftArrow, Perspective: RightArrow},
+		Psychology: Psychology{Motivation: Motivation{Color: 3, Arrow: LeftArrow}, Mind: Perspective{Color: 6}},
+	}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`"Theme":"Markets - External"`,
+		`"NutrType":"Appetite - Consecutive"`,
+		`"Cognition":"Taste"`,
+		`"Variable":"PLR DLL"`,
+		`"Motivation":"Desire - Left"`,
+		`"Mind":"Personal"`,
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("%s does not contain %s", b, want)
+		}
+	}
+
+	var out chart
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out != in {
+		t.Errorf("round trip: got %+v, want %+v", out, in)
+	}
+
+	// старый JSON без стрелки у мотивации и перспективы читается как раньше
+	if err := json.Unmarshal([]byte(`{"Motivation":"Desire","Mind":"Personal"}`), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Motivation != (Motivation{Color: 3}) || out.Mind != (Perspective{Color: 6}) {
+		t.Errorf("old labels: got %+v %+v", out.Motivation, out.Mind)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Variable":"PXX DLL"}`), &out); err == nil {
+		t.Error("want an error for a bad variable")
+	}
+}
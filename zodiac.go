@@ -0,0 +1,95 @@
+package cd_consts_go
+
+import (
+	"math"
+	"strconv"
+)
+
+// тропический или сидерический зодиак с выбранной айанамшей
+type ZodiacMode int
+
+const (
+	Tropical ZodiacMode = iota
+	SiderealLahiri
+	SiderealFaganBradley
+	SiderealRaman
+)
+
+func (zm ZodiacMode) String() string {
+	switch zm {
+	case Tropical:
+		return "Tropical"
+	case SiderealLahiri:
+		return "Sidereal Lahiri"
+	case SiderealFaganBradley:
+		return "Sidereal Fagan-Bradley"
+	case SiderealRaman:
+		return "Sidereal Raman"
+	}
+	return "Unknown Zodiac"
+}
+
+// значения айанамш на J2000 в градусах (как в Swiss Ephemeris)
+var ayanamsaJ2000 = map[ZodiacMode]float64{
+	Tropical:             0,
+	SiderealLahiri:       23.857092,
+	SiderealFaganBradley: 24.740300,
+	SiderealRaman:        22.410791,
+}
+
+// айанамша в градусах на момент secFromJd2000,
+// сдвигается на общую прецессию по долготе 5028.796195" + 1.1054348"*T в столетие
+func Ayanamsa(mode ZodiacMode, secFromJd2000 int64) float64 {
+
+	if mode == Tropical {
+		return 0
+	}
+
+	// юлианские столетия от J2000
+	t := float64(secFromJd2000) / float64(SEC_IN_1_DAY) / 36525.0
+
+	return ayanamsaJ2000[mode] + (5028.796195*t+1.1054348*t*t)/3600.0
+}
+
+// переводит эклиптическую долготу (радианы) в знак, градусы, минуты и секунды.
+// секунды округляются, 29°59'59.5" становится 0°00'00" следующего знака
+func NewZodiacStructure(longitudeRad float64, mode ZodiacMode, secFromJd2000 int64) ZodiacStructure {
+
+	deg := math.Mod(longitudeRad*RAD_TO_DEG-Ayanamsa(mode, secFromJd2000), 360.0)
+	if deg < 0 {
+		deg += 360.0
+	}
+
+	// все считаем в целых угловых секундах, чтобы перенос шел через все разряды сразу.
+	// Как в wheelBase убираем шум от перевода градусы -> радианы -> градусы,
+	// иначе ровно 59.5" может оказаться 59.4999..." и округлиться вниз
+	arcsec := math.Round(deg*3600.0*1e6) / 1e6
+	total := int(math.Round(arcsec))
+	if total >= 360*3600 {
+		total -= 360 * 3600
+	}
+
+	sign := total / (30 * 3600)
+	rest := total % (30 * 3600)
+
+	return ZodiacStructure{
+		Degrees: rest / 3600,
+		Minutes: rest % 3600 / 60,
+		Seconds: rest % 60,
+		Zodiac:  ZodiacNames[sign+1],
+	}
+}
+
+func (zs ZodiacStructure) String() string {
+	return strconv.Itoa(zs.Degrees) + "°" + strconv.Itoa(zs.Minutes) + "'" + strconv.Itoa(zs.Seconds) + "\" " + zs.Zodiac
+}
+
+// заполняет ZodiacStructure для всех планет по их Longitude
+func (pl *Planets) SetZodiac(mode ZodiacMode, secFromJd2000 int64) {
+
+	for i := 1; i < NUMBEROFPLANETSWITHHIRON; i++ {
+		if p := pl.Body(i); p != nil {
+			p.ZodiacStructure = NewZodiacStructure(p.Longitude, mode, secFromJd2000)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package cd_consts_go
+
+import "testing"
+
+func dmsToRad(d, m int, s float64) float64 {
+	return (float64(d) + float64(m)/60 + s/3600) * RAD_RATIO
+}
+
+func TestNewZodiacStructureRounding(t *testing.T) {
+
+	// переменные, чтобы значения считались во время выполнения, а не при компиляции
+	d, m := 29, 59
+
+	tests := []struct {
+		name string
+		rad  float64
+		want string
+	}{
+		{"runtime 29°59'59.5\"", dmsToRad(d, m, 59.5), "0°0'0\" Taurus"},
+		{"const 29°59'59.5\"", (29 + 59.0/60 + 59.5/3600) * RAD_RATIO, "0°0'0\" Taurus"},
+		{"29°59'59.4\"", dmsToRad(d, m, 59.4), "29°59'59\" Aries"},
+		{"359°59'59.5\"", dmsToRad(359, m, 59.5), "0°0'0\" Aries"},
+		{"0°", 0, "0°0'0\" Aries"},
+		{"59°59'59.5\"", dmsToRad(59, m, 59.5), "0°0'0\" Gemini"},
+		{"12°30'30.2\"", dmsToRad(12, 30, 30.2), "12°30'30\" Aries"},
+	}
+
+	for _, tt := range tests {
+		if got := NewZodiacStructure(tt.rad, Tropical, 0).String(); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}